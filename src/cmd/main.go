@@ -23,11 +23,12 @@ func main() {
 
 	env := fetchEnvironment()
 
-	log.Info("Starting http", "env", env)
+	log.Info("Starting http", "env", env, "authMode", cfg.Auth.Mode)
 
-	application := app.New(log, cfg.ServerAddress, cfg.StorageConn)
+	application := app.New(log, cfg.ServerAddress, cfg.StorageConn, cfg.Auth, cfg.SchedulerInterval, cfg.SearchConfigs)
 
 	go application.HTTPServer.MustRun()
+	application.Scheduler.Start()
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
@@ -36,6 +37,7 @@ func main() {
 
 	log.Info("Application stopped", slog.String("signal", sign.String()))
 
+	application.Scheduler.Stop()
 	application.HTTPServer.Stop()
 }
 
@@ -2,40 +2,61 @@ package app
 
 import (
 	"git.codenrock.com/avito/internal/app/http-server"
+	"git.codenrock.com/avito/internal/app/scheduler"
+	"git.codenrock.com/avito/internal/auth"
+	"git.codenrock.com/avito/internal/config"
 	"git.codenrock.com/avito/internal/handlers"
 	"git.codenrock.com/avito/internal/repository/postgres"
 	"git.codenrock.com/avito/internal/routes"
 	"git.codenrock.com/avito/internal/services"
 	"github.com/gin-gonic/gin"
 	"log/slog"
+	"time"
 )
 
 type App struct {
 	HTTPServer *http_server.Server
+	Scheduler  *scheduler.Scheduler
 }
 
-func New(log *slog.Logger, serverPort, storagePath string) *App {
-	storage, err := postgres.New(storagePath)
+func New(log *slog.Logger, serverPort, storagePath string, authCfg config.AuthConfig, schedulerInterval time.Duration, searchConfigs []string) *App {
+	storage, err := postgres.New(storagePath, searchConfigs)
 	if err != nil {
 		panic(err)
 	}
 
+	tokens := auth.NewTokenManager(authCfg.JWTSecret, authCfg.TokenTTL)
+	authService := services.NewAuthService(log, storage, tokens)
+	authHandler := handlers.NewAuthHandler(log, authService, tokens, authCfg.Mode == config.AuthModeCompat)
+
 	tenderService := services.NewTenderService(log, storage)
 	tenderHandler := handlers.NewTenderHandler(log, tenderService)
 
 	bidService := services.NewBidService(log, storage)
 	bidHandler := handlers.NewBidHandler(log, bidService)
 
+	employeeService := services.NewEmployeeService(log, storage)
+	employeeHandler := handlers.NewEmployeeHandler(log, employeeService)
+
+	organizationService := services.NewOrganizationService(log, storage)
+	organizationHandler := handlers.NewOrganizationHandler(log, organizationService)
+
 	r := gin.Default()
 	err = r.SetTrustedProxies(nil)
 	if err != nil {
 		return nil
 	}
-	routes.InitRoutes(r, tenderHandler, bidHandler)
+	routes.InitRoutes(r, authHandler, tenderHandler, bidHandler, employeeHandler, organizationHandler)
 
 	server := http_server.NewServer(log, serverPort, r)
 
+	jobs := scheduler.New(log, schedulerInterval)
+	jobs.Add("publish scheduled tenders", tenderService.PublishScheduledTenders)
+	jobs.Add("close expired tenders", tenderService.CloseExpiredTenders)
+	jobs.Add("close ended auctions", tenderService.CloseEndedAuctions)
+
 	return &App{
 		HTTPServer: server,
+		Scheduler:  jobs,
 	}
 }
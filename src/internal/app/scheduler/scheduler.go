@@ -0,0 +1,92 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type job struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// Scheduler runs background jobs at a fixed interval inside the application process.
+// Every job runs once on start and then on each tick; a failed run is logged and
+// retried on the next tick.
+type Scheduler struct {
+	log      *slog.Logger
+	interval time.Duration
+	jobs     []job
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func New(log *slog.Logger, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		log:      log,
+		interval: interval,
+	}
+}
+
+// Add registers a job. It must be called before Start.
+func (s *Scheduler) Add(name string, run func(ctx context.Context) error) {
+	s.jobs = append(s.jobs, job{name: name, run: run})
+}
+
+func (s *Scheduler) Start() {
+	const op = "Scheduler.Start"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	s.log.Info("Scheduler started", slog.String("op", op), slog.Duration("interval", s.interval), slog.Int("jobs", len(s.jobs)))
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			s.runJobs(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop cancels the running jobs and waits for them to return.
+func (s *Scheduler) Stop() {
+	const op = "Scheduler.Stop"
+
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+
+	s.log.Info("Scheduler stopped", slog.String("op", op))
+}
+
+func (s *Scheduler) runJobs(ctx context.Context) {
+	for _, j := range s.jobs {
+		if ctx.Err() != nil {
+			return
+		}
+
+		runCtx, cancel := context.WithTimeout(ctx, s.interval)
+		err := j.run(runCtx)
+		cancel()
+		if err != nil {
+			s.log.Error("Scheduled job failed", slog.String("job", j.name), slog.String("error", err.Error()))
+		}
+	}
+}
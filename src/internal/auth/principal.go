@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUnauthenticated  = fmt.Errorf("request is not authenticated")
+	ErrIdentityMismatch = fmt.Errorf("claimed identity does not match authenticated user")
+)
+
+// Principal is the employee on whose behalf a request is executed.
+// ID is uuid.Nil when the identity comes from the legacy username parameter.
+type Principal struct {
+	ID       uuid.UUID
+	Username string
+}
+
+type principalKey struct{}
+
+type compatKey struct{}
+
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	if !ok || p.Username == "" {
+		return Principal{}, false
+	}
+	return p, true
+}
+
+// Username returns the username of the authenticated principal.
+func Username(ctx context.Context) (string, error) {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return "", ErrUnauthenticated
+	}
+	return p.Username, nil
+}
+
+// WithCompat marks the request as served in compatibility mode, where identities
+// claimed by the client in the request are trusted.
+func WithCompat(ctx context.Context) context.Context {
+	return context.WithValue(ctx, compatKey{}, true)
+}
+
+func IsCompat(ctx context.Context) bool {
+	compat, _ := ctx.Value(compatKey{}).(bool)
+	return compat
+}
+
+// Resolve returns the acting username for requests that also carry an identity in
+// their body (e.g. creatorUsername). An authenticated principal must match the claim;
+// without one, the claim is accepted only in compatibility mode.
+func Resolve(ctx context.Context, claimed string) (string, error) {
+	if p, ok := PrincipalFromContext(ctx); ok {
+		if claimed != "" && claimed != p.Username {
+			return "", ErrIdentityMismatch
+		}
+		return p.Username, nil
+	}
+
+	if IsCompat(ctx) && claimed != "" {
+		return claimed, nil
+	}
+
+	return "", ErrUnauthenticated
+}
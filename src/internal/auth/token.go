@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidToken = fmt.Errorf("invalid token")
+	ErrTokenExpired = fmt.Errorf("token expired")
+)
+
+// tokenHeader is fixed: only HS256 tokens are issued and accepted.
+var tokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+type claims struct {
+	Subject   string `json:"sub"`
+	Username  string `json:"username"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// TokenManager issues and verifies HS256-signed JWTs for employees.
+type TokenManager struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+func NewTokenManager(secret string, ttl time.Duration) *TokenManager {
+	return &TokenManager{
+		secret: []byte(secret),
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+func (m *TokenManager) Issue(p Principal) (string, time.Time, error) {
+	const op = "auth.TokenManager.Issue"
+
+	now := m.now()
+	expiresAt := now.Add(m.ttl)
+
+	payload, err := json.Marshal(claims{
+		Subject:   p.ID.String(),
+		Username:  p.Username,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	unsigned := tokenHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	return unsigned + "." + m.sign(unsigned), expiresAt, nil
+}
+
+func (m *TokenManager) Parse(token string) (Principal, error) {
+	const op = "auth.TokenManager.Parse"
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != tokenHeader {
+		return Principal{}, fmt.Errorf("%s: %w", op, ErrInvalidToken)
+	}
+
+	expected := m.sign(parts[0] + "." + parts[1])
+	if !hmac.Equal([]byte(parts[2]), []byte(expected)) {
+		return Principal{}, fmt.Errorf("%s: %w", op, ErrInvalidToken)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return Principal{}, fmt.Errorf("%s: %w", op, ErrInvalidToken)
+	}
+
+	var c claims
+	if err = json.Unmarshal(payload, &c); err != nil {
+		return Principal{}, fmt.Errorf("%s: %w", op, ErrInvalidToken)
+	}
+
+	if m.now().Unix() >= c.ExpiresAt {
+		return Principal{}, fmt.Errorf("%s: %w", op, ErrTokenExpired)
+	}
+
+	id, err := uuid.Parse(c.Subject)
+	if err != nil || c.Username == "" {
+		return Principal{}, fmt.Errorf("%s: %w", op, ErrInvalidToken)
+	}
+
+	return Principal{ID: id, Username: c.Username}, nil
+}
+
+func (m *TokenManager) sign(unsigned string) string {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
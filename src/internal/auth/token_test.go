@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"encoding/base64"
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestManager(secret string, now time.Time) *TokenManager {
+	m := NewTokenManager(secret, time.Hour)
+	m.now = func() time.Time { return now }
+	return m
+}
+
+func TestTokenManagerIssueParse(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	m := newTestManager("secret", now)
+	p := Principal{ID: uuid.New(), Username: "user1"}
+
+	token, expiresAt, err := m.Issue(p)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if !expiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("expiresAt = %v, want %v", expiresAt, now.Add(time.Hour))
+	}
+
+	got, err := m.Parse(token)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got != p {
+		t.Errorf("Parse() = %+v, want %+v", got, p)
+	}
+}
+
+func TestTokenManagerParse(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	m := newTestManager("secret", now)
+
+	valid, _, err := m.Issue(Principal{ID: uuid.New(), Username: "user1"})
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	parts := strings.Split(valid, ".")
+
+	otherKey, _, _ := newTestManager("other", now).Issue(Principal{ID: uuid.New(), Username: "user1"})
+	expired, _, _ := newTestManager("secret", now.Add(-2*time.Hour)).Issue(Principal{ID: uuid.New(), Username: "user1"})
+	atExpiry, _, _ := newTestManager("secret", now.Add(-time.Hour)).Issue(Principal{ID: uuid.New(), Username: "user1"})
+	noUsername, _, _ := m.Issue(Principal{ID: uuid.New()})
+
+	// signed forges a token over the given header and payload with the right key.
+	signed := func(header, payload string) string {
+		unsigned := header + "." + base64.RawURLEncoding.EncodeToString([]byte(payload))
+		return unsigned + "." + m.sign(unsigned)
+	}
+	noneHeader := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{"valid", valid, nil},
+		{"empty", "", ErrInvalidToken},
+		{"two parts", parts[0] + "." + parts[1], ErrInvalidToken},
+		{"four parts", valid + ".x", ErrInvalidToken},
+		{"other algorithm", noneHeader + "." + parts[1] + "." + parts[2], ErrInvalidToken},
+		{"tampered payload", parts[0] + "." + parts[1] + "x." + parts[2], ErrInvalidToken},
+		{"tampered signature", parts[0] + "." + parts[1] + "." + parts[2] + "x", ErrInvalidToken},
+		{"signed with another key", otherKey, ErrInvalidToken},
+		{"payload not json", signed(tokenHeader, "not json"), ErrInvalidToken},
+		{"subject not uuid", signed(tokenHeader, `{"sub":"1","username":"user1","exp":1800000000}`), ErrInvalidToken},
+		{"no username", noUsername, ErrInvalidToken},
+		{"expired", expired, ErrTokenExpired},
+		{"at expiry", atExpiry, ErrTokenExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := m.Parse(tt.token)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("Parse() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Parse() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
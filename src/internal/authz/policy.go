@@ -0,0 +1,157 @@
+package authz
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"slices"
+)
+
+var ErrForbidden = fmt.Errorf("permission denied")
+
+type Permission string
+
+const (
+	TenderCreate  Permission = "tender.create"
+	TenderView    Permission = "tender.view"
+	TenderEdit    Permission = "tender.edit"
+	TenderPublish Permission = "tender.publish"
+	TenderHistory Permission = "tender.history"
+	TenderInvite  Permission = "tender.invite"
+	BidCreate     Permission = "bid.create"
+	BidView       Permission = "bid.view"
+	BidEdit       Permission = "bid.edit"
+	BidList       Permission = "bid.list"
+	BidDecide     Permission = "bid.decide"
+	BidScore      Permission = "bid.score"
+	ReviewWrite   Permission = "review.write"
+	ReviewRead    Permission = "review.read"
+
+	QuestionAsk     Permission = "question.ask"
+	QuestionAnswer  Permission = "question.answer"
+	QuestionReadAll Permission = "question.read_all"
+
+	EmployeeView              Permission = "employee.view"
+	EmployeeEdit              Permission = "employee.edit"
+	EmployeeManage            Permission = "employee.manage"
+	OrganizationView          Permission = "organization.view"
+	OrganizationEdit          Permission = "organization.edit"
+	OrganizationManage        Permission = "organization.manage"
+	OrganizationMembersManage Permission = "organization.members.manage"
+)
+
+type Role string
+
+const (
+	// RoleOrgResponsible is a responsible of the organization that owns the tender,
+	// or of the organization itself for organization resources.
+	RoleOrgResponsible Role = "org_responsible"
+	// RoleTenderCreator is the employee who created the tender.
+	RoleTenderCreator Role = "tender_creator"
+	// RoleBidAuthor is the employee who authored the bid or a responsible of the
+	// organization the bid was submitted for.
+	RoleBidAuthor Role = "bid_author"
+	// RoleOrgMember is any employee that belongs to at least one organization.
+	RoleOrgMember Role = "org_member"
+	// RoleInvitedOrg is a responsible of an organization invited to an invite-only tender.
+	RoleInvitedOrg Role = "invited_org"
+	// RoleEmployee is any authenticated employee.
+	RoleEmployee Role = "employee"
+	// RoleSelf is the employee an employee resource describes.
+	RoleSelf Role = "self"
+	// RolePlatformAdmin provisions employees and organizations and may read everything,
+	// but does not act on behalf of organizations.
+	RolePlatformAdmin Role = "platform_admin"
+)
+
+var grants = map[Permission][]Role{
+	TenderCreate:  {RoleOrgResponsible},
+	TenderView:    {RoleOrgResponsible, RoleInvitedOrg, RolePlatformAdmin},
+	TenderEdit:    {RoleTenderCreator},
+	TenderPublish: {RoleTenderCreator},
+	TenderHistory: {RoleOrgResponsible, RolePlatformAdmin},
+	TenderInvite:  {RoleOrgResponsible},
+	BidCreate:     {RoleBidAuthor},
+	BidView:       {RoleBidAuthor, RoleOrgResponsible, RolePlatformAdmin},
+	BidEdit:       {RoleBidAuthor},
+	BidList:       {RoleOrgResponsible, RolePlatformAdmin},
+	BidDecide:     {RoleOrgResponsible},
+	BidScore:      {RoleOrgResponsible},
+	ReviewWrite:   {RoleOrgResponsible},
+	ReviewRead:    {RoleOrgResponsible, RolePlatformAdmin},
+
+	QuestionAsk:     {RoleEmployee},
+	QuestionAnswer:  {RoleOrgResponsible},
+	QuestionReadAll: {RoleOrgResponsible, RolePlatformAdmin},
+
+	EmployeeView:              {RoleSelf, RoleOrgMember, RolePlatformAdmin},
+	EmployeeEdit:              {RoleSelf, RolePlatformAdmin},
+	EmployeeManage:            {RolePlatformAdmin},
+	OrganizationView:          {RoleOrgMember, RolePlatformAdmin},
+	OrganizationEdit:          {RoleOrgResponsible, RolePlatformAdmin},
+	OrganizationManage:        {RolePlatformAdmin},
+	OrganizationMembersManage: {RoleOrgResponsible, RolePlatformAdmin},
+}
+
+// publicPermissions are granted to everyone, including anonymous callers, once the
+// tender is published, unless it is invite-only.
+var publicPermissions = map[Permission]bool{
+	TenderView: true,
+}
+
+// sealedPermissions are held only by the bid author while the bids of the tender are
+// sealed.
+var sealedPermissions = map[Permission]bool{
+	BidView: true,
+}
+
+// Roles returns the roles the subject holds with respect to the resource.
+func Roles(s Subject, r Resource) []Role {
+	if s.IsAnonymous() {
+		return nil
+	}
+
+	roles := []Role{RoleEmployee}
+	if s.Admin {
+		roles = append(roles, RolePlatformAdmin)
+	}
+	if len(s.Organizations) > 0 {
+		roles = append(roles, RoleOrgMember)
+	}
+	if s.IsResponsibleFor(r.TenderOrganizationID) || s.IsResponsibleFor(r.OrganizationID) {
+		roles = append(roles, RoleOrgResponsible)
+	}
+	if slices.ContainsFunc(r.TenderInvitedOrganizations, s.IsResponsibleFor) {
+		roles = append(roles, RoleInvitedOrg)
+	}
+	if r.EmployeeID != uuid.Nil && r.EmployeeID == s.UserID {
+		roles = append(roles, RoleSelf)
+	}
+	if r.TenderCreator != "" && r.TenderCreator == s.Username {
+		roles = append(roles, RoleTenderCreator)
+	}
+	if s.IsResponsibleFor(r.BidOrganizationID) || (r.BidAuthorType == "User" && r.BidAuthorID == s.UserID) {
+		roles = append(roles, RoleBidAuthor)
+	}
+
+	return roles
+}
+
+// Can reports whether the subject holds the permission on the resource.
+func Can(s Subject, p Permission, r Resource) bool {
+	if publicPermissions[p] && r.TenderPublished && !r.TenderInviteOnly {
+		return true
+	}
+
+	for _, role := range Roles(s, r) {
+		if r.BidsSealed && sealedPermissions[p] && role != RoleBidAuthor {
+			continue
+		}
+		for _, granted := range grants[p] {
+			if role == granted {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,150 @@
+package authz
+
+import (
+	"github.com/google/uuid"
+	"slices"
+	"testing"
+)
+
+func TestCan(t *testing.T) {
+	buyerOrg, supplierOrg, invitedOrg := uuid.New(), uuid.New(), uuid.New()
+
+	anonymous := Subject{}
+	creator := Subject{UserID: uuid.New(), Username: "creator", Organizations: []uuid.UUID{buyerOrg}}
+	responsible := Subject{UserID: uuid.New(), Username: "responsible", Organizations: []uuid.UUID{buyerOrg}}
+	supplier := Subject{UserID: uuid.New(), Username: "supplier", Organizations: []uuid.UUID{supplierOrg}}
+	invited := Subject{UserID: uuid.New(), Username: "invited", Organizations: []uuid.UUID{invitedOrg}}
+	loner := Subject{UserID: uuid.New(), Username: "loner"}
+	admin := Subject{UserID: uuid.New(), Username: "admin", Admin: true}
+
+	draft := Resource{TenderOrganizationID: buyerOrg, TenderCreator: "creator"}
+	published := Resource{TenderOrganizationID: buyerOrg, TenderCreator: "creator", TenderPublished: true}
+	inviteOnly := Resource{TenderOrganizationID: buyerOrg, TenderCreator: "creator", TenderPublished: true,
+		TenderInviteOnly: true, TenderInvitedOrganizations: []uuid.UUID{invitedOrg}}
+
+	orgBid := published
+	orgBid.BidOrganizationID = supplierOrg
+	orgBid.BidAuthorType = "Organization"
+	orgBid.BidAuthorID = supplierOrg
+
+	userBid := published
+	userBid.BidAuthorType = "User"
+	userBid.BidAuthorID = loner.UserID
+
+	sealedBid := orgBid
+	sealedBid.BidsSealed = true
+
+	tests := []struct {
+		name    string
+		subject Subject
+		perm    Permission
+		res     Resource
+		want    bool
+	}{
+		{"anonymous views published tender", anonymous, TenderView, published, true},
+		{"anonymous cannot view draft", anonymous, TenderView, draft, false},
+		{"anonymous cannot view invite-only tender", anonymous, TenderView, inviteOnly, false},
+		{"outsider cannot view draft", supplier, TenderView, draft, false},
+		{"responsible views draft", responsible, TenderView, draft, true},
+		{"admin views draft", admin, TenderView, draft, true},
+		{"invited organization views invite-only tender", invited, TenderView, inviteOnly, true},
+		{"outsider cannot view invite-only tender", supplier, TenderView, inviteOnly, false},
+
+		{"responsible creates tender", responsible, TenderCreate, Resource{TenderOrganizationID: buyerOrg}, true},
+		{"outsider cannot create tender", supplier, TenderCreate, Resource{TenderOrganizationID: buyerOrg}, false},
+		{"admin cannot create tender", admin, TenderCreate, Resource{TenderOrganizationID: buyerOrg}, false},
+		{"creator edits tender", creator, TenderEdit, draft, true},
+		{"other responsible cannot edit tender", responsible, TenderEdit, draft, false},
+		{"creator publishes tender", creator, TenderPublish, draft, true},
+		{"other responsible cannot publish tender", responsible, TenderPublish, draft, false},
+		{"responsible reads history", responsible, TenderHistory, draft, true},
+		{"outsider cannot read history", supplier, TenderHistory, published, false},
+
+		{"organization responsible creates bid", supplier, BidCreate, orgBid, true},
+		{"employee creates own bid", loner, BidCreate, userBid, true},
+		{"outsider cannot create bid", invited, BidCreate, orgBid, false},
+		{"author views bid", supplier, BidView, orgBid, true},
+		{"tender responsible views bid", responsible, BidView, orgBid, true},
+		{"admin views bid", admin, BidView, orgBid, true},
+		{"outsider cannot view bid", invited, BidView, orgBid, false},
+		{"author edits bid", supplier, BidEdit, orgBid, true},
+		{"tender responsible cannot edit bid", responsible, BidEdit, orgBid, false},
+		{"tender responsible lists bids", responsible, BidList, published, true},
+		{"author cannot list bids", supplier, BidList, orgBid, false},
+		{"tender responsible decides", responsible, BidDecide, orgBid, true},
+		{"author cannot decide", supplier, BidDecide, orgBid, false},
+		{"admin cannot decide", admin, BidDecide, orgBid, false},
+		{"anonymous cannot decide", anonymous, BidDecide, orgBid, false},
+
+		{"author views sealed bid", supplier, BidView, sealedBid, true},
+		{"tender responsible cannot view sealed bid", responsible, BidView, sealedBid, false},
+		{"admin cannot view sealed bid", admin, BidView, sealedBid, false},
+		{"tender responsible lists sealed bids", responsible, BidList, sealedBid, true},
+
+		{"responsible writes review", responsible, ReviewWrite, orgBid, true},
+		{"author cannot write review", supplier, ReviewWrite, orgBid, false},
+		{"admin reads reviews", admin, ReviewRead, orgBid, true},
+
+		{"any employee asks a question", loner, QuestionAsk, published, true},
+		{"anonymous cannot ask a question", anonymous, QuestionAsk, published, false},
+		{"responsible answers", responsible, QuestionAnswer, published, true},
+		{"outsider cannot answer", supplier, QuestionAnswer, published, false},
+
+		{"employee views self", loner, EmployeeView, Resource{EmployeeID: loner.UserID}, true},
+		{"member views employee", supplier, EmployeeView, Resource{EmployeeID: loner.UserID}, true},
+		{"loner cannot view another employee", loner, EmployeeView, Resource{EmployeeID: supplier.UserID}, false},
+		{"employee edits self", loner, EmployeeEdit, Resource{EmployeeID: loner.UserID}, true},
+		{"employee cannot manage employees", supplier, EmployeeManage, Resource{EmployeeID: loner.UserID}, false},
+		{"admin manages employees", admin, EmployeeManage, Resource{EmployeeID: loner.UserID}, true},
+		{"responsible edits organization", supplier, OrganizationEdit, Resource{OrganizationID: supplierOrg}, true},
+		{"outsider cannot edit organization", responsible, OrganizationEdit, Resource{OrganizationID: supplierOrg}, false},
+		{"responsible manages members", supplier, OrganizationMembersManage, Resource{OrganizationID: supplierOrg}, true},
+		{"responsible cannot manage organizations", supplier, OrganizationManage, Resource{OrganizationID: supplierOrg}, false},
+		{"admin manages organizations", admin, OrganizationManage, Resource{}, true},
+		{"unknown permission", admin, Permission("tender.delete"), draft, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Can(tt.subject, tt.perm, tt.res); got != tt.want {
+				t.Errorf("Can(%s, %s) = %v, want %v", tt.subject.Username, tt.perm, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoles(t *testing.T) {
+	org, other := uuid.New(), uuid.New()
+	user := Subject{UserID: uuid.New(), Username: "user", Organizations: []uuid.UUID{org}}
+
+	tests := []struct {
+		name    string
+		subject Subject
+		res     Resource
+		want    []Role
+	}{
+		{"anonymous", Subject{}, Resource{TenderOrganizationID: org}, nil},
+		{"employee without organizations", Subject{Username: "loner"}, Resource{}, []Role{RoleEmployee}},
+		{"admin", Subject{Username: "admin", Admin: true}, Resource{}, []Role{RoleEmployee, RolePlatformAdmin}},
+		{"member of another organization", user, Resource{TenderOrganizationID: other}, []Role{RoleEmployee, RoleOrgMember}},
+		{"creator and responsible", user, Resource{TenderOrganizationID: org, TenderCreator: "user"},
+			[]Role{RoleEmployee, RoleOrgMember, RoleOrgResponsible, RoleTenderCreator}},
+		{"invited", user, Resource{TenderOrganizationID: other, TenderInvitedOrganizations: []uuid.UUID{org}},
+			[]Role{RoleEmployee, RoleOrgMember, RoleInvitedOrg}},
+		{"self", user, Resource{EmployeeID: user.UserID}, []Role{RoleEmployee, RoleOrgMember, RoleSelf}},
+		{"organization bid author", user, Resource{TenderOrganizationID: other, BidOrganizationID: org},
+			[]Role{RoleEmployee, RoleOrgMember, RoleBidAuthor}},
+		{"user bid author", user, Resource{TenderOrganizationID: other, BidAuthorType: "User", BidAuthorID: user.UserID},
+			[]Role{RoleEmployee, RoleOrgMember, RoleBidAuthor}},
+		{"author id of another type", user, Resource{TenderOrganizationID: other, BidAuthorType: "Organization", BidAuthorID: user.UserID},
+			[]Role{RoleEmployee, RoleOrgMember}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Roles(tt.subject, tt.res); !slices.Equal(got, tt.want) {
+				t.Errorf("Roles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
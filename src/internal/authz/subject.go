@@ -0,0 +1,47 @@
+package authz
+
+import (
+	"github.com/google/uuid"
+	"slices"
+)
+
+// Subject is the employee a permission is evaluated for. The zero Subject is an
+// anonymous caller.
+type Subject struct {
+	UserID        uuid.UUID
+	Username      string
+	Admin         bool
+	Organizations []uuid.UUID
+}
+
+func (s Subject) IsAnonymous() bool {
+	return s.Username == ""
+}
+
+// IsResponsibleFor reports whether the subject is a responsible of the organization.
+func (s Subject) IsResponsibleFor(organizationID uuid.UUID) bool {
+	return organizationID != uuid.Nil && slices.Contains(s.Organizations, organizationID)
+}
+
+// Resource describes the tender and, for bid permissions, the bid an action targets,
+// or the organization or employee for management actions. Fields that do not apply
+// to the action are left zero.
+type Resource struct {
+	OrganizationID uuid.UUID
+	EmployeeID     uuid.UUID
+
+	TenderOrganizationID uuid.UUID
+	TenderCreator        string
+	TenderPublished      bool
+	// TenderInviteOnly hides a published tender from everyone but its organization,
+	// the invited organizations and platform admins.
+	TenderInviteOnly           bool
+	TenderInvitedOrganizations []uuid.UUID
+
+	BidOrganizationID uuid.UUID
+	BidAuthorType     string
+	BidAuthorID       uuid.UUID
+	// BidsSealed is set while the bids of a sealed tender are hidden from everyone but
+	// their authors.
+	BidsSealed bool
+}
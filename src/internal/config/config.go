@@ -1,14 +1,47 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"github.com/joho/godotenv"
 	"log"
 	"os"
+	"strings"
+	"time"
 )
 
+const (
+	AuthModeStrict = "strict"
+	AuthModeCompat = "compat"
+)
+
+// minJWTSecretLength is the shortest HMAC key accepted: 32 bytes, the output size
+// of SHA-256.
+const minJWTSecretLength = 32
+
+// placeholderJWTSecret is the example key once shipped in .env.
+const placeholderJWTSecret = "change-me-in-production"
+
 type Config struct {
 	ServerAddress string
 	StorageConn   string
+	Auth          AuthConfig
+
+	// SchedulerInterval is how often background jobs such as closing expired
+	// tenders run.
+	SchedulerInterval time.Duration
+
+	// SearchConfigs are the PostgreSQL text search configurations tender search
+	// queries are parsed with, the first one also highlights the snippets.
+	SearchConfigs []string
+}
+
+type AuthConfig struct {
+	// Mode is AuthModeStrict (bearer tokens only) or AuthModeCompat, which also
+	// accepts the acting user from the username query parameter.
+	Mode      string
+	JWTSecret string
+	TokenTTL  time.Duration
 }
 
 func MustLoad() *Config {
@@ -30,5 +63,77 @@ func MustLoad() *Config {
 	return &Config{
 		ServerAddress: serverAddress,
 		StorageConn:   postgresURL,
+		Auth:          mustLoadAuth(),
+
+		SchedulerInterval: mustLoadSchedulerInterval(),
+		SearchConfigs:     loadSearchConfigs(),
+	}
+}
+
+// loadSearchConfigs reads the comma separated SEARCH_CONFIGS, defaulting to Russian
+// and English.
+func loadSearchConfigs() []string {
+	var configs []string
+	for _, name := range strings.Split(os.Getenv("SEARCH_CONFIGS"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			configs = append(configs, name)
+		}
+	}
+	if len(configs) == 0 {
+		configs = []string{"russian", "english"}
+	}
+
+	return configs
+}
+
+func mustLoadSchedulerInterval() time.Duration {
+	interval := time.Minute
+	if raw := os.Getenv("SCHEDULER_INTERVAL"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			log.Fatal("SCHEDULER_INTERVAL must be a positive duration")
+		}
+		interval = parsed
+	}
+
+	return interval
+}
+
+func mustLoadAuth() AuthConfig {
+	mode := os.Getenv("AUTH_MODE")
+	if mode == "" {
+		mode = AuthModeStrict
+	}
+	if mode != AuthModeStrict && mode != AuthModeCompat {
+		log.Fatalf("AUTH_MODE must be %q or %q", AuthModeStrict, AuthModeCompat)
+	}
+
+	secret := os.Getenv("JWT_SECRET")
+	if err := validateJWTSecret(secret); err != nil {
+		log.Fatal(err)
+	}
+
+	ttl := 24 * time.Hour
+	if raw := os.Getenv("JWT_TTL"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			log.Fatal("JWT_TTL must be a positive duration")
+		}
+		ttl = parsed
+	}
+
+	return AuthConfig{Mode: mode, JWTSecret: secret, TokenTTL: ttl}
+}
+
+// validateJWTSecret rejects a missing, placeholder or too short signing key.
+func validateJWTSecret(secret string) error {
+	switch {
+	case secret == "":
+		return errors.New("JWT_SECRET is not set")
+	case secret == placeholderJWTSecret:
+		return errors.New("JWT_SECRET is the placeholder value, set a real secret")
+	case len(secret) < minJWTSecretLength:
+		return fmt.Errorf("JWT_SECRET must be at least %d bytes long", minJWTSecretLength)
 	}
+	return nil
 }
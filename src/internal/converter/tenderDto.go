@@ -13,5 +13,32 @@ func ToCreateTenderDTO(tender *models.Tender) dto.TenderDTO {
 		Status:          tender.Status,
 		OrganizationID:  tender.OrganizationID,
 		CreatorUsername: tender.CreatorUsername,
+
+		SubmissionDeadline: tender.SubmissionDeadline,
+		DecisionDeadline:   tender.DecisionDeadline,
+		PublishAt:          tender.PublishAt,
+
+		BudgetMin:   tender.BudgetMin,
+		BudgetMax:   tender.BudgetMax,
+		Currency:    tender.Currency,
+		VATIncluded: tender.VATIncluded,
+		CapAtBudget: tender.CapAtBudget,
+
+		Visibility: tender.Visibility,
+		Sealed:     tender.Sealed,
+
+		Lots: toLotDTOs(tender.Lots),
+	}
+}
+
+func toLotDTOs(lots []models.Lot) []dto.LotDTO {
+	if len(lots) == 0 {
+		return nil
+	}
+
+	result := make([]dto.LotDTO, 0, len(lots))
+	for _, lot := range lots {
+		result = append(result, dto.LotDTO{Name: lot.Name, Description: lot.Description})
 	}
+	return result
 }
@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type AuctionDTO struct {
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	// MinDecrement is the decimal amount every new price must undercut the best one by.
+	MinDecrement string `json:"min_decrement"`
+	// A price placed within ExtensionWindowMinutes of the end moves the end to
+	// ExtensionMinutes after the price.
+	ExtensionWindowMinutes int `json:"extension_window_minutes"`
+	ExtensionMinutes       int `json:"extension_minutes"`
+}
+
+type AuctionPriceDTO struct {
+	Price string `json:"price"`
+}
+
+// TenderAuctionDTO is the public state of an auction. Prices are not attributed to
+// bids until the auction is closed.
+type TenderAuctionDTO struct {
+	TenderID               uuid.UUID  `json:"tender_id"`
+	Status                 string     `json:"status"`
+	StartsAt               time.Time  `json:"starts_at"`
+	EndsAt                 time.Time  `json:"ends_at"`
+	MinDecrement           string     `json:"min_decrement"`
+	ExtensionWindowMinutes int        `json:"extension_window_minutes"`
+	ExtensionMinutes       int        `json:"extension_minutes"`
+	Currency               string     `json:"currency"`
+	BestPrice              *string    `json:"best_price,omitempty"`
+	PriceCount             int        `json:"price_count"`
+	ParticipantCount       int        `json:"participant_count"`
+	WinningBidID           *uuid.UUID `json:"winning_bid_id,omitempty"`
+	ClosedAt               *time.Time `json:"closed_at,omitempty"`
+
+	// LeadingBidID is the bid holding the best price; it is used to pick the winner
+	// and is not exposed while the auction runs.
+	LeadingBidID *uuid.UUID `json:"-"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type BidDecisionDTO struct {
+	Username  string    `json:"username"`
+	Decision  string    `json:"decision"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type BidDecisionsDTO struct {
+	BidID      uuid.UUID        `json:"bid_id"`
+	Status     string           `json:"status"`
+	Approvals  int              `json:"approvals"`
+	Rejections int              `json:"rejections"`
+	Quorum     int              `json:"quorum"`
+	Decisions  []BidDecisionDTO `json:"decisions"`
+}
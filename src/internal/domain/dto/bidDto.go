@@ -6,16 +6,35 @@ import (
 )
 
 type BidDTO struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	TenderID    int    `json:"tender_id"`
-	AuthorType  string `json:"author_type"`
-	AuthorID    int    `json:"author_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	TenderID    uuid.UUID `json:"tender_id"`
+	AuthorType  string    `json:"author_type"`
+	AuthorID    uuid.UUID `json:"author_id"`
+	// OrganizationID picks the organization a User author bids for. It is required
+	// when the employee is responsible for several organizations that may bid.
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty"`
+
+	// Price is a decimal string. With line items it defaults to their total.
+	Price    *string      `json:"price,omitempty"`
+	Currency string       `json:"currency,omitempty"`
+	Items    []BidItemDTO `json:"items,omitempty"`
+
+	// LotIDs are the lots the bid is for. A tender with lots requires at least one.
+	LotIDs []uuid.UUID `json:"lot_ids,omitempty"`
+}
+
+type BidItemDTO struct {
+	Name      string `json:"name"`
+	Quantity  string `json:"quantity"`
+	UnitPrice string `json:"unit_price"`
+	Amount    string `json:"amount,omitempty"`
 }
 
 type UpdateBidDTO struct {
-	Name        string `json:"name,omitempty"`
-	Description string `json:"description,omitempty"`
+	Name            string `json:"name,omitempty"`
+	Description     string `json:"description,omitempty"`
+	ExpectedVersion int    `json:"expectedVersion,omitempty"`
 }
 
 type BidResponseDTO struct {
@@ -23,8 +42,25 @@ type BidResponseDTO struct {
 	Name       string    `json:"name"`
 	Status     string    `json:"status"`
 	AuthorType string    `json:"author_type"`
-	AuthorID   int       `json:"author_id"`
+	AuthorID   uuid.UUID `json:"author_id"`
 	Version    int       `json:"version"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
+
+	// WithdrawalReason and WithdrawnAt are set while the bid is withdrawn.
+	WithdrawalReason string     `json:"withdrawal_reason,omitempty"`
+	WithdrawnAt      *time.Time `json:"withdrawn_at,omitempty"`
+
+	Price    *string      `json:"price,omitempty"`
+	Currency string       `json:"currency,omitempty"`
+	Items    []BidItemDTO `json:"items,omitempty"`
+	LotIDs   []uuid.UUID  `json:"lot_ids,omitempty"`
+
+	// Sealed marks a bid listed before the submission deadline of a sealed tender;
+	// only its metadata is returned.
+	Sealed bool `json:"sealed,omitempty"`
+}
+
+type WithdrawBidDTO struct {
+	Reason string `json:"reason"`
 }
@@ -1,9 +1,16 @@
 package dto
 
-import "time"
+import (
+	"github.com/google/uuid"
+	"time"
+)
 
 type BidReviewDTO struct {
-	ID          string    `json:"id"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"createdAt"`
+	ID               uuid.UUID `json:"id"`
+	Description      string    `json:"description"`
+	BidID            uuid.UUID `json:"bid_id"`
+	TenderID         uuid.UUID `json:"tender_id"`
+	OrganizationID   uuid.UUID `json:"organization_id"`
+	ReviewerUsername string    `json:"reviewer_username"`
+	CreatedAt        time.Time `json:"createdAt"`
 }
@@ -0,0 +1,31 @@
+package dto
+
+import "github.com/google/uuid"
+
+// BidComparisonDTO lines up the selected bids of a tender against the same columns:
+// every bid lists the criteria in the order of Criteria.
+type BidComparisonDTO struct {
+	TenderID uuid.UUID            `json:"tender_id"`
+	Currency string               `json:"currency,omitempty"`
+	Criteria []TenderCriterionDTO `json:"criteria"`
+	Bids     []ComparedBidDTO     `json:"bids"`
+}
+
+type ComparedBidDTO struct {
+	BidID            uuid.UUID `json:"bid_id"`
+	Name             string    `json:"name"`
+	Status           string    `json:"status"`
+	Version          int       `json:"version"`
+	AuthorType       string    `json:"author_type"`
+	AuthorID         uuid.UUID `json:"author_id"`
+	OrganizationID   uuid.UUID `json:"organization_id"`
+	OrganizationName string    `json:"organization_name"`
+	Price            *string   `json:"price,omitempty"`
+	Currency         string    `json:"currency,omitempty"`
+	// PriceIndex is the lowest compared price as a percentage of this bid's price, so
+	// the cheapest bid gets 100.
+	PriceIndex  string              `json:"price_index,omitempty"`
+	ReviewCount int                 `json:"review_count"`
+	Scores      []CriterionScoreDTO `json:"scores"`
+	Total       string              `json:"total"`
+}
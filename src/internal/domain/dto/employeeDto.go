@@ -0,0 +1,18 @@
+package dto
+
+type CreateEmployeeDTO struct {
+	Username  string `json:"username" binding:"required"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Password  string `json:"password,omitempty"`
+	IsAdmin   bool   `json:"is_admin"`
+}
+
+// UpdateEmployeeDTO changes only the fields that are set. An empty password keeps
+// the current one.
+type UpdateEmployeeDTO struct {
+	FirstName *string `json:"first_name,omitempty"`
+	LastName  *string `json:"last_name,omitempty"`
+	Password  string  `json:"password,omitempty"`
+	IsAdmin   *bool   `json:"is_admin,omitempty"`
+}
@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Weights and scores are decimal strings. Weights of a tender add up to 100, scores
+// range from 0 to 100.
+
+type CriterionDTO struct {
+	Name   string `json:"name"`
+	Weight string `json:"weight"`
+}
+
+type TenderCriterionDTO struct {
+	ID        uuid.UUID `json:"id"`
+	TenderID  uuid.UUID `json:"tender_id"`
+	Position  int       `json:"position"`
+	Name      string    `json:"name"`
+	Weight    string    `json:"weight"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ScoreDTO struct {
+	CriterionID uuid.UUID `json:"criterion_id"`
+	Score       string    `json:"score"`
+	Comment     string    `json:"comment,omitempty"`
+}
+
+type BidScoreDTO struct {
+	BidID         uuid.UUID `json:"bid_id"`
+	CriterionID   uuid.UUID `json:"criterion_id"`
+	CriterionName string    `json:"criterion_name"`
+	Username      string    `json:"username"`
+	Score         string    `json:"score"`
+	Comment       string    `json:"comment,omitempty"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type CriterionScoreDTO struct {
+	CriterionID uuid.UUID `json:"criterion_id"`
+	Name        string    `json:"name"`
+	Weight      string    `json:"weight"`
+	// Average is empty until someone has scored the bid on the criterion.
+	Average string `json:"average,omitempty"`
+	Scorers int    `json:"scorers"`
+}
+
+type BidRankingDTO struct {
+	Rank     int                 `json:"rank"`
+	BidID    uuid.UUID           `json:"bid_id"`
+	BidName  string              `json:"bid_name"`
+	Status   string              `json:"status"`
+	Total    string              `json:"total"`
+	Criteria []CriterionScoreDTO `json:"criteria"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type InvitationDTO struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+}
+
+type TenderInvitationDTO struct {
+	TenderID         uuid.UUID `json:"tender_id"`
+	OrganizationID   uuid.UUID `json:"organization_id"`
+	OrganizationName string    `json:"organization_name"`
+	InvitedBy        string    `json:"invited_by"`
+	CreatedAt        time.Time `json:"created_at"`
+}
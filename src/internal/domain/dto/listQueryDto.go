@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// SortField orders a listing by one field, descending when Desc is set.
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// ListQueryDTO is the filter, sort and paging model shared by the listing endpoints.
+// Zero fields do not filter. The repository decides which fields a listing supports
+// and rejects the rest with repository.ErrInvalidListQuery.
+type ListQueryDTO struct {
+	// Query is a web-search style full-text query; only tender listings support it.
+	Query          string
+	Statuses       []string
+	OrganizationID uuid.UUID
+	Creator        string
+	ServiceTypes   []string
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+	UpdatedFrom    *time.Time
+	UpdatedTo      *time.Time
+
+	Sort   []SortField
+	Limit  int
+	Offset int
+	// After continues a listing ordered by created_at after the given row.
+	After *Cursor
+}
@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type LotDTO struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type TenderLotDTO struct {
+	ID           uuid.UUID  `json:"id"`
+	TenderID     uuid.UUID  `json:"tender_id"`
+	Position     int        `json:"position"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	Status       string     `json:"status"`
+	AwardedBidID *uuid.UUID `json:"awarded_bid_id,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
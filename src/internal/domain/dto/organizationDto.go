@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"git.codenrock.com/avito/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type OrganizationDTO struct {
+	Name        string                  `json:"name" binding:"required"`
+	Description string                  `json:"description"`
+	Type        models.OrganizationType `json:"type" binding:"required"`
+}
+
+// UpdateOrganizationDTO changes only the fields that are set.
+type UpdateOrganizationDTO struct {
+	Name        *string                  `json:"name,omitempty"`
+	Description *string                  `json:"description,omitempty"`
+	Type        *models.OrganizationType `json:"type,omitempty"`
+}
+
+// AddResponsibleDTO names the employee by id or by username.
+type AddResponsibleDTO struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username"`
+}
@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"encoding/base64"
+	"errors"
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	tests := []struct {
+		name   string
+		cursor Cursor
+	}{
+		{"utc", Cursor{CreatedAt: time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC), ID: uuid.New()}},
+		{"nanoseconds", Cursor{CreatedAt: time.Date(2024, 9, 1, 12, 0, 0, 123456789, time.UTC), ID: uuid.New()}},
+		{"other zone", Cursor{CreatedAt: time.Date(2024, 9, 1, 15, 0, 0, 0, moscow), ID: uuid.New()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeCursor(tt.cursor.Encode())
+			if err != nil {
+				t.Fatalf("DecodeCursor() error = %v", err)
+			}
+			if !got.CreatedAt.Equal(tt.cursor.CreatedAt) || got.ID != tt.cursor.ID {
+				t.Errorf("DecodeCursor() = %+v, want %+v", got, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	encode := func(raw string) string { return base64.RawURLEncoding.EncodeToString([]byte(raw)) }
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"empty", ""},
+		{"not base64", "!!!"},
+		{"no separator", encode("2024-09-01T12:00:00Z")},
+		{"bad time", encode("yesterday|" + uuid.NewString())},
+		{"bad id", encode("2024-09-01T12:00:00Z|42")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeCursor(tt.value); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("DecodeCursor(%q) error = %v, want %v", tt.value, err, ErrInvalidCursor)
+			}
+		})
+	}
+}
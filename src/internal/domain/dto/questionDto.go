@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type QuestionDTO struct {
+	Question string `json:"question"`
+}
+
+type AnswerDTO struct {
+	Answer string `json:"answer"`
+	// Public answers are visible to everyone who can see the tender, private ones
+	// only to the author of the question and the tender organization.
+	Public bool `json:"public"`
+}
+
+type TenderQuestionDTO struct {
+	ID             uuid.UUID  `json:"id"`
+	TenderID       uuid.UUID  `json:"tender_id"`
+	AuthorUsername string     `json:"author_username"`
+	Question       string     `json:"question"`
+	Answer         string     `json:"answer,omitempty"`
+	AnsweredBy     string     `json:"answered_by,omitempty"`
+	Public         bool       `json:"public"`
+	CreatedAt      time.Time  `json:"created_at"`
+	AnsweredAt     *time.Time `json:"answered_at,omitempty"`
+}
@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"encoding/json"
 	"github.com/google/uuid"
 	"time"
 )
@@ -12,14 +13,70 @@ type TenderDTO struct {
 	Status          string    `json:"status"`
 	OrganizationID  uuid.UUID `json:"organization_id"`
 	CreatorUsername string    `json:"creator_username"`
+
+	SubmissionDeadline *time.Time `json:"submission_deadline,omitempty"`
+	DecisionDeadline   *time.Time `json:"decision_deadline,omitempty"`
+	PublishAt          *time.Time `json:"publish_at,omitempty"`
+
+	// Amounts are decimal strings, e.g. "1500000.00".
+	BudgetMin   *string `json:"budget_min,omitempty"`
+	BudgetMax   *string `json:"budget_max,omitempty"`
+	Currency    string  `json:"currency,omitempty"`
+	VATIncluded bool    `json:"vat_included"`
+	CapAtBudget bool    `json:"cap_at_budget"`
+
+	// Visibility is Public or InviteOnly; empty means Public.
+	Visibility string `json:"visibility,omitempty"`
+	// Sealed hides the contents of bids from the tender organization until the
+	// submission deadline, which a sealed tender must have.
+	Sealed bool `json:"sealed"`
+
+	Lots []LotDTO `json:"lots,omitempty"`
 }
 
 type UpdateTenderDTO struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Status      string    `json:"status"`
-	ServiceType string    `json:"service_type"`
+	ID              uuid.UUID `json:"id"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	Status          string    `json:"status"`
+	ServiceType     string    `json:"service_type"`
+	ExpectedVersion int       `json:"expectedVersion,omitempty"`
+
+	// An absent deadline is left unchanged, an explicit null clears it.
+	SubmissionDeadline OptionalTime `json:"submission_deadline"`
+	DecisionDeadline   OptionalTime `json:"decision_deadline"`
+	PublishAt          OptionalTime `json:"publish_at"`
+
+	BudgetMin   *string `json:"budget_min,omitempty"`
+	BudgetMax   *string `json:"budget_max,omitempty"`
+	Currency    string  `json:"currency,omitempty"`
+	VATIncluded *bool   `json:"vat_included,omitempty"`
+	CapAtBudget *bool   `json:"cap_at_budget,omitempty"`
+
+	Visibility string `json:"visibility,omitempty"`
+	Sealed     *bool  `json:"sealed,omitempty"`
+}
+
+// OptionalTime is a timestamp of a partial update. Set tells a field missing from the
+// request apart from one set to null.
+type OptionalTime struct {
+	Set  bool
+	Time *time.Time
+}
+
+func (t *OptionalTime) UnmarshalJSON(data []byte) error {
+	t.Set = true
+	t.Time = nil
+	if string(data) == "null" {
+		return nil
+	}
+
+	var value time.Time
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	t.Time = &value
+	return nil
 }
 
 type TenderResponseDTO struct {
@@ -33,4 +90,34 @@ type TenderResponseDTO struct {
 	CreatedAt       time.Time `json:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at"`
 	Version         int       `json:"version"`
+
+	SubmissionDeadline *time.Time `json:"submission_deadline,omitempty"`
+	DecisionDeadline   *time.Time `json:"decision_deadline,omitempty"`
+	PublishAt          *time.Time `json:"publish_at,omitempty"`
+
+	BudgetMin   *string `json:"budget_min,omitempty"`
+	BudgetMax   *string `json:"budget_max,omitempty"`
+	Currency    string  `json:"currency,omitempty"`
+	VATIncluded bool    `json:"vat_included"`
+	CapAtBudget bool    `json:"cap_at_budget"`
+
+	Visibility string `json:"visibility"`
+	Sealed     bool   `json:"sealed"`
+	// InvitedOrganizations is used for access checks and is not exposed; the tender
+	// organization reads it from the invitations endpoint.
+	InvitedOrganizations []uuid.UUID `json:"-"`
+
+	// Lots is only set in the response to creating a tender.
+	Lots []TenderLotDTO `json:"lots,omitempty"`
+
+	// Rank and Snippet are only set for full-text search results.
+	Rank    float32 `json:"rank,omitempty"`
+	Snippet string  `json:"snippet,omitempty"`
+}
+
+type TenderStatusChangeDTO struct {
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status"`
+	Actor      string    `json:"actor"`
+	CreatedAt  time.Time `json:"created_at"`
 }
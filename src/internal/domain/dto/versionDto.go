@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type VersionDTO struct {
+	Version     int       `json:"version"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	ServiceType string    `json:"service_type,omitempty"`
+	Status      string    `json:"status"`
+	EditedBy    string    `json:"edited_by"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	// The commercial terms are only recorded for bids.
+	Price    *string      `json:"price,omitempty"`
+	Currency string       `json:"currency,omitempty"`
+	Items    []BidItemDTO `json:"items,omitempty"`
+	LotIDs   []uuid.UUID  `json:"lot_ids,omitempty"`
+}
+
+type FieldChangeDTO struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+type VersionEditDTO struct {
+	Version  int              `json:"version"`
+	EditedBy string           `json:"edited_by"`
+	EditedAt time.Time        `json:"edited_at"`
+	Changes  []FieldChangeDTO `json:"changes"`
+}
+
+type VersionDiffDTO struct {
+	From    int              `json:"from"`
+	To      int              `json:"to"`
+	Changes []FieldChangeDTO `json:"changes"`
+	Edits   []VersionEditDTO `json:"edits"`
+}
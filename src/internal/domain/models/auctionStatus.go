@@ -0,0 +1,14 @@
+package models
+
+// AuctionStatus is the state of a reverse auction. An open auction accepts prices
+// between its start and its (possibly extended) end; a closed one has picked its winner.
+type AuctionStatus string
+
+const (
+	AuctionStatusOpen   AuctionStatus = "Open"
+	AuctionStatusClosed AuctionStatus = "Closed"
+)
+
+func (s AuctionStatus) String() string {
+	return string(s)
+}
@@ -1,10 +1,20 @@
 package models
 
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
 type Bid struct {
-	Name            string `json:"name"`
-	Description     string `json:"description"`
-	Status          string `json:"status"`
-	TenderID        int    `json:"tender_id"`
-	OrganizationID  int    `json:"organization_id"`
-	CreatorUsername string `json:"creator_username"`
+	ID             uuid.UUID `json:"id"`
+	Name           string    `json:"name"`
+	Description    string    `json:"description"`
+	Status         BidStatus `json:"status"`
+	TenderID       uuid.UUID `json:"tender_id"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+	AuthorType     string    `json:"author_type"`
+	AuthorID       uuid.UUID `json:"author_id"`
+	Version        int       `json:"version"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
 }
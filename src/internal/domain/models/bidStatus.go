@@ -0,0 +1,73 @@
+package models
+
+import "strings"
+
+type BidStatus string
+
+const (
+	BidStatusCreated   BidStatus = "Created"
+	BidStatusPublished BidStatus = "Published"
+	BidStatusCanceled  BidStatus = "Canceled"
+	BidStatusApproved  BidStatus = "Approved"
+	BidStatusRejected  BidStatus = "Rejected"
+	// BidStatusWithdrawn is entered and left only through withdrawal and resubmission,
+	// which record the reason and invalidate the decisions made on the bid.
+	BidStatusWithdrawn BidStatus = "Withdrawn"
+)
+
+// BidActor tells who drives a bid transition: the bid author side publishes and
+// cancels, the responsibles of the tender organization approve and reject.
+type BidActor int
+
+const (
+	BidActorAuthor BidActor = iota
+	BidActorReviewer
+)
+
+var bidTransitions = map[BidActor]map[BidStatus][]BidStatus{
+	BidActorAuthor: {
+		BidStatusCreated:   {BidStatusPublished, BidStatusCanceled},
+		BidStatusPublished: {BidStatusCanceled},
+	},
+	BidActorReviewer: {
+		BidStatusPublished: {BidStatusApproved, BidStatusRejected},
+	},
+}
+
+var bidStatuses = []BidStatus{BidStatusCreated, BidStatusPublished, BidStatusCanceled, BidStatusApproved, BidStatusRejected,
+	BidStatusWithdrawn}
+
+// ParseBidStatus accepts a status in any letter case and returns its canonical form.
+func ParseBidStatus(s string) (BidStatus, bool) {
+	for _, status := range bidStatuses {
+		if strings.EqualFold(string(status), s) {
+			return status, true
+		}
+	}
+	return "", false
+}
+
+func (s BidStatus) CanTransitionTo(next BidStatus, actor BidActor) bool {
+	for _, allowed := range bidTransitions[actor][s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDecided reports whether the bid has been approved or rejected. Decided bids
+// can be neither edited nor rolled back.
+func (s BidStatus) IsDecided() bool {
+	return s == BidStatusApproved || s == BidStatusRejected
+}
+
+// IsEditable reports whether the author may still change the bid: while it is
+// created or published, and while it is withdrawn for revision before resubmission.
+func (s BidStatus) IsEditable() bool {
+	return s == BidStatusCreated || s == BidStatusPublished || s == BidStatusWithdrawn
+}
+
+func (s BidStatus) String() string {
+	return string(s)
+}
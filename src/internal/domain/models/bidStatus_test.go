@@ -0,0 +1,106 @@
+package models
+
+import "testing"
+
+func TestBidStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to BidStatus
+		actor    BidActor
+		want     bool
+	}{
+		{"author publishes", BidStatusCreated, BidStatusPublished, BidActorAuthor, true},
+		{"author cancels created", BidStatusCreated, BidStatusCanceled, BidActorAuthor, true},
+		{"author cancels published", BidStatusPublished, BidStatusCanceled, BidActorAuthor, true},
+		{"author cannot approve", BidStatusPublished, BidStatusApproved, BidActorAuthor, false},
+		{"author cannot reopen canceled", BidStatusCanceled, BidStatusPublished, BidActorAuthor, false},
+		{"author cannot withdraw by status", BidStatusPublished, BidStatusWithdrawn, BidActorAuthor, false},
+		{"author cannot leave withdrawn", BidStatusWithdrawn, BidStatusPublished, BidActorAuthor, false},
+		{"reviewer approves", BidStatusPublished, BidStatusApproved, BidActorReviewer, true},
+		{"reviewer rejects", BidStatusPublished, BidStatusRejected, BidActorReviewer, true},
+		{"reviewer cannot decide created", BidStatusCreated, BidStatusApproved, BidActorReviewer, false},
+		{"reviewer cannot publish", BidStatusCreated, BidStatusPublished, BidActorReviewer, false},
+		{"reviewer cannot cancel", BidStatusPublished, BidStatusCanceled, BidActorReviewer, false},
+		{"approved is final", BidStatusApproved, BidStatusRejected, BidActorReviewer, false},
+		{"rejected is final", BidStatusRejected, BidStatusApproved, BidActorReviewer, false},
+		{"unknown actor", BidStatusCreated, BidStatusPublished, BidActor(42), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to, tt.actor); got != tt.want {
+				t.Errorf("%s.CanTransitionTo(%s) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBidStatus(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   BidStatus
+		wantOK bool
+	}{
+		{"Created", BidStatusCreated, true},
+		{"published", BidStatusPublished, true},
+		{"CANCELED", BidStatusCanceled, true},
+		{"Approved", BidStatusApproved, true},
+		{"rejected", BidStatusRejected, true},
+		{"withdrawn", BidStatusWithdrawn, true},
+		{"Cancelled", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, ok := ParseBidStatus(tt.in)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("ParseBidStatus(%q) = %q, %v, want %q, %v", tt.in, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestBidStatusIsDecided(t *testing.T) {
+	tests := []struct {
+		status BidStatus
+		want   bool
+	}{
+		{BidStatusCreated, false},
+		{BidStatusPublished, false},
+		{BidStatusCanceled, false},
+		{BidStatusApproved, true},
+		{BidStatusRejected, true},
+		{BidStatusWithdrawn, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status.String(), func(t *testing.T) {
+			if got := tt.status.IsDecided(); got != tt.want {
+				t.Errorf("IsDecided() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBidStatusIsEditable(t *testing.T) {
+	tests := []struct {
+		status BidStatus
+		want   bool
+	}{
+		{BidStatusCreated, true},
+		{BidStatusPublished, true},
+		{BidStatusCanceled, false},
+		{BidStatusApproved, false},
+		{BidStatusRejected, false},
+		{BidStatusWithdrawn, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status.String(), func(t *testing.T) {
+			if got := tt.status.IsEditable(); got != tt.want {
+				t.Errorf("IsEditable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package models
+
+// LotStatus is the state of a tender lot. A tender with lots is closed once none of
+// them is open any more.
+type LotStatus string
+
+const (
+	LotStatusOpen      LotStatus = "Open"
+	LotStatusAwarded   LotStatus = "Awarded"
+	LotStatusCancelled LotStatus = "Cancelled"
+)
+
+func (s LotStatus) String() string {
+	return string(s)
+}
@@ -13,6 +13,14 @@ const (
 	JSC OrganizationType = "JSC"
 )
 
+func (t OrganizationType) IsValid() bool {
+	switch t {
+	case IE, LLC, JSC:
+		return true
+	}
+	return false
+}
+
 type Organization struct {
 	ID          uuid.UUID        `json:"id"`
 	Name        string           `json:"name"`
@@ -1,6 +1,9 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"time"
+)
 
 type Tender struct {
 	Name            string    `json:"name"`
@@ -9,4 +12,24 @@ type Tender struct {
 	Status          string    `json:"status"`
 	OrganizationID  uuid.UUID `json:"organization_id"`
 	CreatorUsername string    `json:"creator_username"`
+
+	SubmissionDeadline *time.Time `json:"submission_deadline,omitempty"`
+	DecisionDeadline   *time.Time `json:"decision_deadline,omitempty"`
+	PublishAt          *time.Time `json:"publish_at,omitempty"`
+
+	BudgetMin   *string `json:"budget_min,omitempty"`
+	BudgetMax   *string `json:"budget_max,omitempty"`
+	Currency    string  `json:"currency,omitempty"`
+	VATIncluded bool    `json:"vat_included"`
+	CapAtBudget bool    `json:"cap_at_budget"`
+
+	Visibility string `json:"visibility,omitempty"`
+	Sealed     bool   `json:"sealed"`
+
+	Lots []Lot `json:"lots,omitempty"`
+}
+
+type Lot struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
 }
@@ -0,0 +1,42 @@
+package models
+
+import "strings"
+
+type TenderStatus string
+
+const (
+	TenderStatusCreated   TenderStatus = "Created"
+	TenderStatusPublished TenderStatus = "Published"
+	TenderStatusClosed    TenderStatus = "Closed"
+)
+
+// tenderTransitions lists the statuses a tender may move to from each status.
+// Closed -> Published is a reopen and is additionally guarded by the service.
+var tenderTransitions = map[TenderStatus][]TenderStatus{
+	TenderStatusCreated:   {TenderStatusPublished, TenderStatusClosed},
+	TenderStatusPublished: {TenderStatusClosed},
+	TenderStatusClosed:    {TenderStatusPublished},
+}
+
+// ParseTenderStatus accepts a status in any letter case and returns its canonical form.
+func ParseTenderStatus(s string) (TenderStatus, bool) {
+	for status := range tenderTransitions {
+		if strings.EqualFold(string(status), s) {
+			return status, true
+		}
+	}
+	return "", false
+}
+
+func (s TenderStatus) CanTransitionTo(next TenderStatus) bool {
+	for _, allowed := range tenderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+func (s TenderStatus) String() string {
+	return string(s)
+}
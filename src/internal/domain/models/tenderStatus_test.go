@@ -0,0 +1,52 @@
+package models
+
+import "testing"
+
+func TestTenderStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from, to TenderStatus
+		want     bool
+	}{
+		{TenderStatusCreated, TenderStatusPublished, true},
+		{TenderStatusCreated, TenderStatusClosed, true},
+		{TenderStatusCreated, TenderStatusCreated, false},
+		{TenderStatusPublished, TenderStatusClosed, true},
+		{TenderStatusPublished, TenderStatusCreated, false},
+		{TenderStatusPublished, TenderStatusPublished, false},
+		{TenderStatusClosed, TenderStatusPublished, true},
+		{TenderStatusClosed, TenderStatusCreated, false},
+		{TenderStatusClosed, TenderStatusClosed, false},
+		{TenderStatus("Unknown"), TenderStatusPublished, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from.String()+"->"+tt.to.String(), func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("CanTransitionTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTenderStatus(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   TenderStatus
+		wantOK bool
+	}{
+		{"Created", TenderStatusCreated, true},
+		{"PUBLISHED", TenderStatusPublished, true},
+		{"closed", TenderStatusClosed, true},
+		{"", "", false},
+		{"Open", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, ok := ParseTenderStatus(tt.in)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("ParseTenderStatus(%q) = %q, %v, want %q, %v", tt.in, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
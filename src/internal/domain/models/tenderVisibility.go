@@ -0,0 +1,26 @@
+package models
+
+import "strings"
+
+// TenderVisibility controls who may see a published tender and bid on it. Invite-only
+// tenders are open to the organizations on their invitation list.
+type TenderVisibility string
+
+const (
+	TenderVisibilityPublic     TenderVisibility = "Public"
+	TenderVisibilityInviteOnly TenderVisibility = "InviteOnly"
+)
+
+// ParseTenderVisibility accepts a visibility in any letter case and returns its canonical form.
+func ParseTenderVisibility(s string) (TenderVisibility, bool) {
+	for _, visibility := range []TenderVisibility{TenderVisibilityPublic, TenderVisibilityInviteOnly} {
+		if strings.EqualFold(string(visibility), s) {
+			return visibility, true
+		}
+	}
+	return "", false
+}
+
+func (v TenderVisibility) String() string {
+	return string(v)
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (h *TenderHandler) GetTenderAuction(c *gin.Context) {
+	auction, err := h.tenderService.GetTenderAuction(c.Request.Context(), c.Param("tenderId"))
+	if err != nil {
+		writeError(c, h.log, err, auctionErrors)
+		return
+	}
+	c.JSON(http.StatusOK, auction)
+}
+
+func (h *TenderHandler) ConfigureAuction(c *gin.Context) {
+	var auction dto.AuctionDTO
+	if err := c.BindJSON(&auction); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": err.Error()})
+		return
+	}
+
+	configured, err := h.tenderService.ConfigureAuction(c.Request.Context(), c.Param("tenderId"), auction)
+	if err != nil {
+		writeError(c, h.log, err, auctionErrors)
+		return
+	}
+	c.JSON(http.StatusOK, configured)
+}
+
+func (h *TenderHandler) CloseAuction(c *gin.Context) {
+	auction, err := h.tenderService.CloseAuction(c.Request.Context(), c.Param("tenderId"))
+	if err != nil {
+		writeError(c, h.log, err, auctionErrors)
+		return
+	}
+	c.JSON(http.StatusOK, auction)
+}
+
+func (h *BidHandler) PlaceAuctionPrice(c *gin.Context) {
+	var price dto.AuctionPriceDTO
+	if err := c.BindJSON(&price); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": err.Error()})
+		return
+	}
+
+	auction, err := h.bidService.PlaceAuctionPrice(c.Request.Context(), c.Param("id"), price)
+	if err != nil {
+		writeError(c, h.log, err, auctionErrors)
+		return
+	}
+	c.JSON(http.StatusOK, auction)
+}
+
+var auctionErrors = []errorCase{
+	{repository.ErrTenderNotFound, http.StatusNotFound, "Tender not found"},
+	{repository.ErrBidNotFound, http.StatusNotFound, "Bid not found"},
+	{repository.ErrAuctionNotFound, http.StatusNotFound, "Tender has no auction"},
+	{repository.ErrInvalidAuction, http.StatusBadRequest, "Invalid auction settings"},
+	{repository.ErrInvalidAmount, http.StatusBadRequest, "Price must be a positive amount"},
+	{repository.ErrPriceAboveBudget, http.StatusBadRequest, "Price exceeds the tender budget"},
+	{repository.ErrAuctionLocked, http.StatusConflict, "Auction can only be configured before the tender is published"},
+	{repository.ErrAuctionNotOpen, http.StatusConflict, "Auction is not accepting prices"},
+	{repository.ErrAuctionRunning, http.StatusConflict, "Auction has not ended yet"},
+	{repository.ErrPriceNotLowEnough, http.StatusConflict, "Price must undercut the best price by the minimum decrement"},
+	{repository.ErrIllegalBidTransition, http.StatusConflict, "Only published bids take part in the auction"},
+	{repository.ErrLotNotOpen, http.StatusConflict, "All lots of the winning bid have been settled"},
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"git.codenrock.com/avito/internal/auth"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/services"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+type AuthService interface {
+	Login(ctx context.Context, username, password string) (dto.TokenDTO, error)
+}
+
+type TokenParser interface {
+	Parse(token string) (auth.Principal, error)
+}
+
+type AuthHandler struct {
+	log         *slog.Logger
+	authService AuthService
+	tokens      TokenParser
+	compat      bool
+}
+
+// NewAuthHandler creates the handler for token issuing and request authentication.
+// With compat set, requests without a bearer token act as the user named in the
+// username query parameter, as the original API did.
+func NewAuthHandler(log *slog.Logger, authService *services.AuthService, tokens TokenParser, compat bool) *AuthHandler {
+	return &AuthHandler{
+		log:         log,
+		authService: authService,
+		tokens:      tokens,
+		compat:      compat,
+	}
+}
+
+func (h *AuthHandler) Login(c *gin.Context) {
+	var credentials dto.LoginDTO
+	if err := c.ShouldBindJSON(&credentials); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "Username and password are required"})
+		return
+	}
+
+	token, err := h.authService.Login(c.Request.Context(), credentials.Username, credentials.Password)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidCredentials):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Invalid username or password"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"reason": "Failed to issue token"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, token)
+}
+
+// Authenticate puts the request principal into the request context. A present but
+// invalid bearer token is always rejected; a missing one leaves the request anonymous.
+func (h *AuthHandler) Authenticate(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	if header := c.GetHeader("Authorization"); header != "" {
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"reason": "Unsupported authorization scheme"})
+			return
+		}
+
+		principal, err := h.tokens.Parse(token)
+		if err != nil {
+			h.log.Warn("Rejected bearer token", slog.String("error", err.Error()))
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"reason": "Invalid or expired token"})
+			return
+		}
+
+		ctx = auth.WithPrincipal(ctx, principal)
+	} else if h.compat {
+		ctx = auth.WithCompat(ctx)
+		username := c.Query("username")
+		if username == "" {
+			username = c.Query("requesterUsername")
+		}
+		if username != "" {
+			ctx = auth.WithPrincipal(ctx, auth.Principal{Username: username})
+		}
+	}
+
+	c.Request = c.Request.WithContext(ctx)
+	c.Next()
+}
+
+// RequireAuth rejects anonymous requests. In compatibility mode the check is left to
+// the services, since the acting user may also be named in the request body.
+func (h *AuthHandler) RequireAuth(c *gin.Context) {
+	ctx := c.Request.Context()
+	if _, ok := auth.PrincipalFromContext(ctx); !ok && !auth.IsCompat(ctx) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		return
+	}
+	c.Next()
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"git.codenrock.com/avito/internal/auth"
+	"git.codenrock.com/avito/internal/authz"
 	"git.codenrock.com/avito/internal/domain/dto"
 	"git.codenrock.com/avito/internal/repository"
 	"git.codenrock.com/avito/internal/services"
@@ -15,15 +17,26 @@ import (
 
 type BidService interface {
 	CreateBid(ctx context.Context, bid *dto.BidDTO) (dto.BidResponseDTO, error)
-	GetUserBids(ctx context.Context, username string, limit, offset int) ([]dto.BidResponseDTO, error)
-	GetTenderBids(ctx context.Context, tenderID, username string, limit, offset int) ([]dto.BidResponseDTO, error)
-	GetBidStatus(ctx context.Context, bidID string, username string) (string, error)
-	UpdateBid(ctx context.Context, bidID string, username string, updates dto.UpdateBidDTO) (dto.BidResponseDTO, error)
-	UpdateBidStatus(ctx context.Context, bidID string, status string, username string) (dto.BidResponseDTO, error)
-	SubmitDecision(ctx context.Context, bidID string, decision string, username string) (dto.BidResponseDTO, error)
-	SendFeedback(ctx context.Context, bidID string, feedback, username string) (dto.BidResponseDTO, error)
-	RollbackBidVersion(ctx context.Context, bidID string, version int, username string) (dto.BidResponseDTO, error)
-	GetBidReviews(ctx context.Context, tenderID, authorUsername, requesterUsername string, limit, offset int) ([]dto.BidReviewDTO, error)
+	GetUserBids(ctx context.Context, q dto.ListQueryDTO) (dto.PageDTO[dto.BidResponseDTO], error)
+	GetTenderBids(ctx context.Context, tenderID string, q dto.ListQueryDTO) (dto.PageDTO[dto.BidResponseDTO], error)
+	GetBidStatus(ctx context.Context, bidID string) (string, int, error)
+	UpdateBid(ctx context.Context, bidID string, updates dto.UpdateBidDTO, expectedVersion int) (dto.BidResponseDTO, error)
+	UpdateBidStatus(ctx context.Context, bidID string, status string, expectedVersion int) (dto.BidResponseDTO, error)
+	SubmitDecision(ctx context.Context, bidID string, decision string) (dto.BidResponseDTO, error)
+	GetBidDecisions(ctx context.Context, bidID string) (dto.BidDecisionsDTO, error)
+	SendFeedback(ctx context.Context, bidID string, feedback string) (dto.BidResponseDTO, error)
+	RollbackBidVersion(ctx context.Context, bidID string, version int, expectedVersion int) (dto.BidResponseDTO, error)
+	GetBidReviews(ctx context.Context, tenderID, authorUsername string, q dto.ListQueryDTO) (dto.PageDTO[dto.BidReviewDTO], error)
+	GetBidVersions(ctx context.Context, bidID string) ([]dto.VersionDTO, error)
+	GetBidVersion(ctx context.Context, bidID string, version int) (dto.VersionDTO, error)
+	GetBidDiff(ctx context.Context, bidID string, from, to int) (dto.VersionDiffDTO, error)
+	GetBidScores(ctx context.Context, bidID string) ([]dto.BidScoreDTO, error)
+	ScoreBid(ctx context.Context, bidID string, scores []dto.ScoreDTO) ([]dto.BidScoreDTO, error)
+	CompareTenderBids(ctx context.Context, tenderID string, bidIDs []string) (dto.BidComparisonDTO, error)
+	WithdrawBid(ctx context.Context, bidID string, withdrawal dto.WithdrawBidDTO, expectedVersion int) (dto.BidResponseDTO, error)
+	ResubmitBid(ctx context.Context, bidID string, expectedVersion int) (dto.BidResponseDTO, error)
+	PlaceAuctionPrice(ctx context.Context, bidID string, price dto.AuctionPriceDTO) (dto.TenderAuctionDTO, error)
+	CloseAuction(ctx context.Context, tenderID string) (dto.TenderAuctionDTO, error)
 }
 
 type BidHandler struct {
@@ -47,7 +60,38 @@ func (h *BidHandler) CreateBid(c *gin.Context) {
 
 	bid, err := h.bidService.CreateBid(c.Request.Context(), bidDTO)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"reason": "Ошибка при создании предложения"})
+		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		case errors.Is(err, authz.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrInvalidAuthorType):
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "Неверный тип автора"})
+		case errors.Is(err, repository.ErrTenderNotFound), errors.Is(err, repository.ErrOrganizationNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"reason": "Тендер или организация не найдены"})
+		case errors.Is(err, repository.ErrNoAssociationWithOrganization):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Пользователь не связан с организацией"})
+		case errors.Is(err, repository.ErrOrganizationNotInvited):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Организация не приглашена к участию в тендере"})
+		case errors.Is(err, repository.ErrAmbiguousOrganization):
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "Пользователь связан с несколькими организациями, укажите organization_id"})
+		case errors.Is(err, auth.ErrIdentityMismatch), errors.Is(err, repository.ErrNoPermission):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Недостаточно прав для выполнения действия"})
+		case errors.Is(err, repository.ErrSubmissionClosed):
+			c.JSON(http.StatusConflict, gin.H{"reason": "Срок подачи предложений истёк"})
+		case errors.Is(err, repository.ErrTenderNotPublished):
+			c.JSON(http.StatusConflict, gin.H{"reason": "Тендер не опубликован"})
+		case errors.Is(err, repository.ErrInvalidAmount), errors.Is(err, repository.ErrInvalidCurrency):
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "Неверная цена или позиции предложения"})
+		case errors.Is(err, repository.ErrCurrencyMismatch):
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "Валюта предложения не совпадает с валютой тендера"})
+		case errors.Is(err, repository.ErrPriceAboveBudget):
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "Цена предложения превышает бюджет тендера"})
+		case errors.Is(err, repository.ErrInvalidBidLots):
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "Лоты предложения не соответствуют открытым лотам тендера"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"reason": "Ошибка при создании предложения"})
+		}
 		return
 	}
 
@@ -56,108 +100,137 @@ func (h *BidHandler) CreateBid(c *gin.Context) {
 }
 
 func (h *BidHandler) GetUserBids(c *gin.Context) {
-	username := c.Query("username")
-	if username == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"reason": "Username is required"})
-		return
-	}
-
-	limit, err := strconv.Atoi(c.DefaultQuery("limit", "5"))
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid limit value"})
-	}
-
-	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	q, err := parseListQuery(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid offset value"})
+		c.JSON(http.StatusBadRequest, gin.H{"reason": err.Error()})
+		return
 	}
 
-	bids, err := h.bidService.GetUserBids(context.Background(), username, limit, offset)
+	bids, err := h.bidService.GetUserBids(c.Request.Context(), q)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"reason": err.Error()})
+		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		case errors.Is(err, authz.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrInvalidListQuery):
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "Unsupported filter or sort field"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"reason": err.Error()})
+		}
 		return
 	}
 
-	c.JSON(http.StatusOK, bids)
+	writePage(c, bids)
 }
 
 func (h *BidHandler) GetTenderBids(c *gin.Context) {
-	tenderID := c.Param("tenderId")
+	tenderID := c.Param("id")
 	if tenderID == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"reason": "tenderId is required"})
+		return
 	}
 
-	username := c.Query("username")
-	if username == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"reason": "username is required"})
-	}
-
-	limit, err := strconv.Atoi(c.DefaultQuery("limit", "5"))
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid limit value"})
-	}
-
-	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	q, err := parseListQuery(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid offset value"})
-	}
-
-	if tenderID == "" || username == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"reason": "tenderId and username are required"})
+		c.JSON(http.StatusBadRequest, gin.H{"reason": err.Error()})
 		return
 	}
 
-	bids, err := h.bidService.GetTenderBids(c, tenderID, username, limit, offset)
+	bids, err := h.bidService.GetTenderBids(c.Request.Context(), tenderID, q)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		case errors.Is(err, authz.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrInvalidListQuery):
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "Unsupported filter or sort field"})
+		case errors.Is(err, repository.ErrTenderNotFound), errors.Is(err, sql.ErrNoRows):
 			c.JSON(http.StatusNotFound, gin.H{"reason": "No bids found for this tender"})
-		} else {
+		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"reason": err.Error()})
 		}
 		return
 	}
 
-	c.JSON(http.StatusOK, bids)
+	writePage(c, bids)
 }
 
 func (h *BidHandler) GetBidStatus(c *gin.Context) {
-	bidID := c.Param("bidId")
-	username := c.Query("username")
+	bidID := c.Param("id")
 
-	status, err := h.bidService.GetBidStatus(c.Request.Context(), bidID, username)
+	status, version, err := h.bidService.GetBidStatus(c.Request.Context(), bidID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"reason": err.Error()})
+		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		case errors.Is(err, authz.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrBidNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"reason": "Bid not found"})
+		case errors.Is(err, repository.ErrNoPermission):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"reason": err.Error()})
+		}
+		return
 	}
 
+	setETag(c, version)
 	c.JSON(http.StatusOK, status)
 }
 
 func (h *BidHandler) UpdateBid(c *gin.Context) {
-	bidID := c.Param("bidId")
+	bidID := c.Param("id")
 	if bidID == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"reason": "Bid id is required"})
-	}
-
-	username := c.Query("username")
-	if username == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"reason": "Username is required"})
+		return
 	}
 
 	var updateBidDTO dto.UpdateBidDTO
 	if err := c.ShouldBindJSON(&updateBidDTO); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"reason": "Ошибка при обработке данных запроса"})
+		return
 	}
 
-	updatedBid, err := h.bidService.UpdateBid(c.Request.Context(), bidID, username, updateBidDTO)
+	expected, err := expectedVersion(c, updateBidDTO.ExpectedVersion)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"reason": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid If-Match header"})
+		return
 	}
 
+	updatedBid, err := h.bidService.UpdateBid(c.Request.Context(), bidID, updateBidDTO, expected)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		case errors.Is(err, authz.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrVersionConflict):
+			c.JSON(http.StatusPreconditionFailed, gin.H{"reason": "Bid was modified by another request"})
+		case errors.Is(err, repository.ErrBidNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"reason": "Bid not found"})
+		case errors.Is(err, repository.ErrNoPermission):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrBidAlreadyDecided):
+			c.JSON(http.StatusConflict, gin.H{"reason": "Bid has already been decided"})
+		case errors.Is(err, repository.ErrIllegalBidTransition):
+			c.JSON(http.StatusConflict, gin.H{"reason": "Bid cannot be edited in its current status"})
+		case errors.Is(err, repository.ErrSubmissionClosed):
+			c.JSON(http.StatusConflict, gin.H{"reason": "Tender submission deadline has passed"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"reason": err.Error()})
+		}
+		return
+	}
+
+	setETag(c, updatedBid.Version)
 	c.JSON(http.StatusOK, updatedBid)
 }
 
 func (h *BidHandler) UpdateBidStatus(c *gin.Context) {
-	bidID := c.Param("bidId")
+	bidID := c.Param("id")
 
 	status := c.Query("status")
 	if status == "" {
@@ -165,30 +238,41 @@ func (h *BidHandler) UpdateBidStatus(c *gin.Context) {
 		return
 	}
 
-	username := c.Query("username")
-	if username == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"reason": "Username is required"})
+	expected, err := expectedVersion(c, 0)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid If-Match header"})
 		return
 	}
 
-	updatedBid, err := h.bidService.UpdateBidStatus(c.Request.Context(), bidID, status, username)
+	updatedBid, err := h.bidService.UpdateBidStatus(c.Request.Context(), bidID, status, expected)
 	if err != nil {
 		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		case errors.Is(err, authz.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrVersionConflict):
+			c.JSON(http.StatusPreconditionFailed, gin.H{"reason": "Bid was modified by another request"})
 		case errors.Is(err, repository.ErrBidNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"reason": "Bid not found"})
 		case errors.Is(err, repository.ErrNoPermission):
 			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrInvalidBidStatus):
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid bid status"})
+		case errors.Is(err, repository.ErrIllegalBidTransition):
+			c.JSON(http.StatusConflict, gin.H{"reason": "Bid status transition is not allowed"})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"reason": "Failed to update bid status"})
 		}
 		return
 	}
 
+	setETag(c, updatedBid.Version)
 	c.JSON(http.StatusOK, updatedBid)
 }
 
 func (h *BidHandler) SubmitDecision(c *gin.Context) {
-	bidID := c.Param("bidId")
+	bidID := c.Param("id")
 	if bidID == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"reason": "Bid id is required"})
 		return
@@ -200,25 +284,39 @@ func (h *BidHandler) SubmitDecision(c *gin.Context) {
 		return
 	}
 
-	username := c.Query("username")
-	if username == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"reason": "Username is required"})
-		return
-	}
-
 	if decision != "Approved" && decision != "Rejected" {
 		c.JSON(http.StatusBadRequest, gin.H{"reason": "Неверное значение решения"})
 		return
 	}
 
-	bidResult, err := h.bidService.SubmitDecision(c.Request.Context(), bidID, decision, username)
+	bidResult, err := h.bidService.SubmitDecision(c.Request.Context(), bidID, decision)
 	if err != nil {
-		switch err {
-		case repository.ErrBidNotFound:
+		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		case errors.Is(err, authz.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrBidNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"reason": "Предложение не найдено"})
-		case repository.ErrNoPermission:
+		case errors.Is(err, repository.ErrNoPermission):
 			c.JSON(http.StatusForbidden, gin.H{"reason": "Недостаточно прав для выполнения действия"})
-		case repository.ErrTenderCloseFailed:
+		case errors.Is(err, repository.ErrIllegalBidTransition):
+			c.JSON(http.StatusConflict, gin.H{"reason": "Решение по предложению в текущем статусе недоступно"})
+		case errors.Is(err, repository.ErrDecisionAlreadySubmitted):
+			c.JSON(http.StatusConflict, gin.H{"reason": "Решение по предложению уже отправлено"})
+		case errors.Is(err, repository.ErrLotNotOpen):
+			c.JSON(http.StatusConflict, gin.H{"reason": "Все лоты предложения уже разыграны или отменены"})
+		case errors.Is(err, repository.ErrBidsSealed):
+			c.JSON(http.StatusConflict, gin.H{"reason": "Предложения запечатаны до окончания срока подачи"})
+		case errors.Is(err, repository.ErrDecisionClosed):
+			c.JSON(http.StatusConflict, gin.H{"reason": "Срок принятия решения истёк"})
+		case errors.Is(err, repository.ErrAuctionRunning):
+			c.JSON(http.StatusConflict, gin.H{"reason": "Аукцион по тендеру ещё не закрыт"})
+		case errors.Is(err, repository.ErrNotAuctionWinner):
+			c.JSON(http.StatusConflict, gin.H{"reason": "Согласовать можно только победителя аукциона"})
+		case errors.Is(err, repository.ErrConcurrentUpdate):
+			c.JSON(http.StatusConflict, gin.H{"reason": "Предложение одновременно изменяется, повторите запрос"})
+		case errors.Is(err, repository.ErrTenderCloseFailed):
 			c.JSON(http.StatusInternalServerError, gin.H{"reason": "Не удалось закрыть тендер"})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"reason": "Внутренняя ошибка сервера"})
@@ -226,19 +324,41 @@ func (h *BidHandler) SubmitDecision(c *gin.Context) {
 		return
 	}
 
+	setETag(c, bidResult.Version)
 	c.JSON(http.StatusOK, bidResult)
 }
 
-func (h *BidHandler) SendFeedback(c *gin.Context) {
-	bidID := c.Param("bidId")
+func (h *BidHandler) GetBidDecisions(c *gin.Context) {
+	bidID := c.Param("id")
 	if bidID == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"reason": "Bid id is required"})
 		return
 	}
 
-	username := c.Query("username")
-	if username == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"reason": "Username is required"})
+	decisions, err := h.bidService.GetBidDecisions(c.Request.Context(), bidID)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		case errors.Is(err, authz.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrBidNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"reason": "Bid not found"})
+		case errors.Is(err, repository.ErrNoPermission):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"reason": "Failed to get bid decisions"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, decisions)
+}
+
+func (h *BidHandler) SendFeedback(c *gin.Context) {
+	bidID := c.Param("id")
+	if bidID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "Bid id is required"})
 		return
 	}
 
@@ -248,98 +368,218 @@ func (h *BidHandler) SendFeedback(c *gin.Context) {
 		return
 	}
 
-	updatedBid, err := h.bidService.SendFeedback(c.Request.Context(), bidID, feedback, username)
+	updatedBid, err := h.bidService.SendFeedback(c.Request.Context(), bidID, feedback)
 	if err != nil {
 		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		case errors.Is(err, authz.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
 		case errors.Is(err, repository.ErrBidNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"reason": "Bid not found"})
 		case errors.Is(err, repository.ErrNoPermission):
 			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrBidsSealed):
+			c.JSON(http.StatusConflict, gin.H{"reason": "Bids are sealed until the submission deadline"})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"reason": "Failed to submit feedback"})
 		}
 		return
 	}
 
+	setETag(c, updatedBid.Version)
 	c.JSON(http.StatusOK, updatedBid)
 }
 
 func (h *BidHandler) RollbackBidVersion(c *gin.Context) {
-	bidID := c.Param("bidId")
+	bidID := c.Param("id")
 	if bidID == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"reason": "Bid id is required"})
+		return
 	}
 
 	versionParam := c.Param("version")
 	if versionParam == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"reason": "Version is required"})
+		return
 	}
 
 	version, err := strconv.Atoi(versionParam)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid version format"})
+		return
 	}
 
-	username := c.Query("username")
-	if username == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"reason": "Username is required"})
+	expected, err := expectedVersion(c, 0)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid If-Match header"})
+		return
 	}
 
-	updatedBid, err := h.bidService.RollbackBidVersion(c.Request.Context(), bidID, version, username)
+	updatedBid, err := h.bidService.RollbackBidVersion(c.Request.Context(), bidID, version, expected)
 	if err != nil {
 		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		case errors.Is(err, authz.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrVersionConflict):
+			c.JSON(http.StatusPreconditionFailed, gin.H{"reason": "Bid was modified by another request"})
 		case errors.Is(err, repository.ErrBidNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"reason": "Bid not found"})
 		case errors.Is(err, repository.ErrVersionNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"reason": "Version not found"})
 		case errors.Is(err, repository.ErrNoPermission):
 			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrBidAlreadyDecided):
+			c.JSON(http.StatusConflict, gin.H{"reason": "Bid has already been decided"})
+		case errors.Is(err, repository.ErrSubmissionClosed):
+			c.JSON(http.StatusConflict, gin.H{"reason": "Tender submission deadline has passed"})
+		case errors.Is(err, repository.ErrInvalidBidLots):
+			c.JSON(http.StatusConflict, gin.H{"reason": "Lots of the version are no longer open"})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"reason": "Failed to rollback bid version"})
 		}
 		return
 	}
 
+	setETag(c, updatedBid.Version)
 	c.JSON(http.StatusOK, updatedBid)
 }
 
 func (h *BidHandler) GetBidReviews(c *gin.Context) {
-	tenderID := c.Param("tenderId")
+	tenderID := c.Param("id")
 
 	authorUsername := c.Query("authorUsername")
 	if authorUsername == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"reason": "Author username is required"})
+		return
 	}
 
-	requesterUsername := c.Query("requesterUsername")
-	if requesterUsername == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"reason": "Requester username is required"})
-	}
-
-	limit, err := strconv.Atoi(c.DefaultQuery("limit", "5"))
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid limit value"})
-	}
-
-	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	q, err := parseListQuery(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid offset value"})
+		c.JSON(http.StatusBadRequest, gin.H{"reason": err.Error()})
+		return
 	}
 
-	reviews, err := h.bidService.GetBidReviews(c.Request.Context(), tenderID, authorUsername, requesterUsername, limit, offset)
+	reviews, err := h.bidService.GetBidReviews(c.Request.Context(), tenderID, authorUsername, q)
 	if err != nil {
 		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		case errors.Is(err, authz.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
 		case errors.Is(err, repository.ErrNoPermission):
 			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
 		case errors.Is(err, repository.ErrTenderNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"reason": "Tender not found"})
+		case errors.Is(err, repository.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"reason": "Author not found"})
+		case errors.Is(err, repository.ErrBidNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"reason": "Author has no bids on the tender"})
 		case errors.Is(err, repository.ErrReviewsNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"reason": "Reviews not found"})
+		case errors.Is(err, repository.ErrInvalidListQuery):
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "Unsupported filter or sort field"})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"reason": "Failed to get reviews"})
 		}
 		return
 	}
 
-	c.JSON(http.StatusOK, reviews)
+	writePage(c, reviews)
+}
+
+func (h *BidHandler) GetBidVersions(c *gin.Context) {
+	bidID := c.Param("id")
+
+	versions, err := h.bidService.GetBidVersions(c.Request.Context(), bidID)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		case errors.Is(err, authz.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrBidNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"reason": "Bid not found"})
+		case errors.Is(err, repository.ErrVersionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"reason": "Version not found"})
+		case errors.Is(err, repository.ErrNoPermission):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"reason": "Failed to get bid versions"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, versions)
+}
+
+func (h *BidHandler) GetBidVersion(c *gin.Context) {
+	bidID := c.Param("id")
+
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid version format"})
+		return
+	}
+
+	bidVersion, err := h.bidService.GetBidVersion(c.Request.Context(), bidID, version)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		case errors.Is(err, authz.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrBidNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"reason": "Bid not found"})
+		case errors.Is(err, repository.ErrVersionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"reason": "Version not found"})
+		case errors.Is(err, repository.ErrNoPermission):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"reason": "Failed to get bid versions"})
+		}
+		return
+	}
+
+	setETag(c, bidVersion.Version)
+	c.JSON(http.StatusOK, bidVersion)
+}
+
+func (h *BidHandler) GetBidDiff(c *gin.Context) {
+	bidID := c.Param("id")
+
+	from, err := strconv.Atoi(c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid from version"})
+		return
+	}
+
+	to, err := strconv.Atoi(c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid to version"})
+		return
+	}
+
+	diff, err := h.bidService.GetBidDiff(c.Request.Context(), bidID, from, to)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		case errors.Is(err, authz.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrBidNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"reason": "Bid not found"})
+		case errors.Is(err, repository.ErrVersionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"reason": "Version not found"})
+		case errors.Is(err, repository.ErrNoPermission):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"reason": "Failed to get bid versions"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
 }
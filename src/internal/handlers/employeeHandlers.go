@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/repository"
+	"git.codenrock.com/avito/internal/services"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type EmployeeHandler struct {
+	log             *slog.Logger
+	employeeService *services.EmployeeService
+}
+
+func NewEmployeeHandler(log *slog.Logger, employeeService *services.EmployeeService) *EmployeeHandler {
+	return &EmployeeHandler{
+		log:             log,
+		employeeService: employeeService,
+	}
+}
+
+func (h *EmployeeHandler) CreateEmployee(c *gin.Context) {
+	var employee dto.CreateEmployeeDTO
+	if err := c.ShouldBindJSON(&employee); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": err.Error()})
+		return
+	}
+
+	user, err := h.employeeService.CreateEmployee(c.Request.Context(), employee)
+	if err != nil {
+		writeError(c, h.log, err, employeeErrors)
+		return
+	}
+	c.JSON(http.StatusCreated, user)
+}
+
+func (h *EmployeeHandler) GetEmployees(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "5"))
+	if err != nil || limit < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid limit"})
+		return
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid offset"})
+		return
+	}
+
+	users, err := h.employeeService.GetEmployees(c.Request.Context(), limit, offset)
+	if err != nil {
+		writeError(c, h.log, err, employeeErrors)
+		return
+	}
+	c.JSON(http.StatusOK, users)
+}
+
+func (h *EmployeeHandler) GetEmployee(c *gin.Context) {
+	user, err := h.employeeService.GetEmployee(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeError(c, h.log, err, employeeErrors)
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *EmployeeHandler) UpdateEmployee(c *gin.Context) {
+	var data dto.UpdateEmployeeDTO
+	if err := c.ShouldBindJSON(&data); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": err.Error()})
+		return
+	}
+
+	user, err := h.employeeService.UpdateEmployee(c.Request.Context(), c.Param("id"), data)
+	if err != nil {
+		writeError(c, h.log, err, employeeErrors)
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *EmployeeHandler) DeleteEmployee(c *gin.Context) {
+	if err := h.employeeService.DeleteEmployee(c.Request.Context(), c.Param("id")); err != nil {
+		writeError(c, h.log, err, employeeErrors)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+var employeeErrors = []errorCase{
+	{repository.ErrUserNotFound, http.StatusNotFound, "Employee not found"},
+	{repository.ErrUserAlreadyExists, http.StatusConflict, "Employee with this username already exists"},
+	{repository.ErrEntityInUse, http.StatusConflict, "Employee is referenced by bids or decisions"},
+	{services.ErrInvalidPassword, http.StatusBadRequest, services.ErrInvalidPassword.Error()},
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"errors"
+	"git.codenrock.com/avito/internal/auth"
+	"git.codenrock.com/avito/internal/authz"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+)
+
+// errorCase maps an error returned by a service to the status and reason of the
+// response.
+type errorCase struct {
+	err    error
+	status int
+	reason string
+}
+
+// writeError responds with the first case err matches. Authentication, permission and
+// concurrent update errors are mapped for every endpoint; anything unexpected is
+// logged and answered with a generic 500, so internal details never reach the client.
+func writeError(c *gin.Context, log *slog.Logger, err error, cases []errorCase) {
+	switch {
+	case errors.Is(err, auth.ErrUnauthenticated):
+		c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		return
+	case errors.Is(err, authz.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		return
+	case errors.Is(err, repository.ErrConcurrentUpdate):
+		c.JSON(http.StatusConflict, gin.H{"reason": "Concurrent update, please retry"})
+		return
+	}
+
+	for _, ec := range cases {
+		if errors.Is(err, ec.err) {
+			c.JSON(ec.status, gin.H{"reason": ec.reason})
+			return
+		}
+	}
+
+	log.Error("Request failed", slog.String("path", c.FullPath()), slog.String("error", err.Error()))
+	c.JSON(http.StatusInternalServerError, gin.H{"reason": "Internal server error"})
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"strconv"
+	"strings"
+)
+
+var errInvalidIfMatch = errors.New("invalid If-Match header")
+
+// expectedVersion returns the entity version the client based its change on.
+// It is read from the If-Match header, then the expectedVersion query
+// parameter, then fallback (usually a request body field). Zero means the
+// client did not ask for a version check.
+func expectedVersion(c *gin.Context, fallback int) (int, error) {
+	if header := strings.TrimSpace(c.GetHeader("If-Match")); header != "" {
+		if header == "*" {
+			return 0, nil
+		}
+
+		tag := strings.TrimPrefix(header, "W/")
+		tag = strings.Trim(tag, `"`)
+		version, err := strconv.Atoi(tag)
+		if err != nil || version < 1 {
+			return 0, errInvalidIfMatch
+		}
+		return version, nil
+	}
+
+	if query := c.Query("expectedVersion"); query != "" {
+		version, err := strconv.Atoi(query)
+		if err != nil || version < 1 {
+			return 0, errInvalidIfMatch
+		}
+		return version, nil
+	}
+
+	return fallback, nil
+}
+
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", `"`+strconv.Itoa(version)+`"`)
+}
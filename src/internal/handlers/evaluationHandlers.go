@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (h *TenderHandler) GetTenderCriteria(c *gin.Context) {
+	criteria, err := h.tenderService.GetTenderCriteria(c.Request.Context(), c.Param("tenderId"))
+	if err != nil {
+		writeError(c, h.log, err, evaluationErrors)
+		return
+	}
+	c.JSON(http.StatusOK, criteria)
+}
+
+func (h *TenderHandler) ReplaceTenderCriteria(c *gin.Context) {
+	var criteria []dto.CriterionDTO
+	if err := c.BindJSON(&criteria); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": err.Error()})
+		return
+	}
+
+	replaced, err := h.tenderService.ReplaceTenderCriteria(c.Request.Context(), c.Param("tenderId"), criteria)
+	if err != nil {
+		writeError(c, h.log, err, evaluationErrors)
+		return
+	}
+	c.JSON(http.StatusOK, replaced)
+}
+
+func (h *TenderHandler) GetTenderRanking(c *gin.Context) {
+	ranking, err := h.tenderService.GetTenderRanking(c.Request.Context(), c.Param("tenderId"))
+	if err != nil {
+		writeError(c, h.log, err, evaluationErrors)
+		return
+	}
+	c.JSON(http.StatusOK, ranking)
+}
+
+func (h *BidHandler) GetBidScores(c *gin.Context) {
+	scores, err := h.bidService.GetBidScores(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeError(c, h.log, err, evaluationErrors)
+		return
+	}
+	c.JSON(http.StatusOK, scores)
+}
+
+func (h *BidHandler) ScoreBid(c *gin.Context) {
+	var scores []dto.ScoreDTO
+	if err := c.BindJSON(&scores); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": err.Error()})
+		return
+	}
+
+	saved, err := h.bidService.ScoreBid(c.Request.Context(), c.Param("id"), scores)
+	if err != nil {
+		writeError(c, h.log, err, evaluationErrors)
+		return
+	}
+	c.JSON(http.StatusOK, saved)
+}
+
+var evaluationErrors = []errorCase{
+	{repository.ErrTenderNotFound, http.StatusNotFound, "Tender not found"},
+	{repository.ErrBidNotFound, http.StatusNotFound, "Bid not found"},
+	{repository.ErrCriterionNotFound, http.StatusBadRequest, "Criterion does not belong to the tender"},
+	{repository.ErrInvalidCriteria, http.StatusBadRequest, "Criteria need names and positive weights adding up to 100"},
+	{repository.ErrInvalidScore, http.StatusBadRequest, "Scores must be between 0 and 100, one per criterion"},
+	{repository.ErrCriteriaLocked, http.StatusConflict, "Criteria cannot change once bids have been scored"},
+	{repository.ErrBidNotScorable, http.StatusConflict, "Only published bids of a published tender can be scored"},
+	{repository.ErrBidsSealed, http.StatusConflict, "Bids are sealed until the submission deadline"},
+	{repository.ErrInvalidComparison, http.StatusBadRequest, "Pass from 1 to 20 bid ids in ids"},
+}
+
+func (h *BidHandler) CompareTenderBids(c *gin.Context) {
+	comparison, err := h.bidService.CompareTenderBids(c.Request.Context(), c.Param("tenderId"), queryList(c, "ids"))
+	if err != nil {
+		writeError(c, h.log, err, evaluationErrors)
+		return
+	}
+	c.JSON(http.StatusOK, comparison)
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (h *TenderHandler) GetTenderInvitations(c *gin.Context) {
+	invitations, err := h.tenderService.GetTenderInvitations(c.Request.Context(), c.Param("tenderId"))
+	if err != nil {
+		writeError(c, h.log, err, invitationErrors)
+		return
+	}
+	c.JSON(http.StatusOK, invitations)
+}
+
+func (h *TenderHandler) InviteOrganization(c *gin.Context) {
+	var invitation dto.InvitationDTO
+	if err := c.BindJSON(&invitation); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": err.Error()})
+		return
+	}
+
+	invited, err := h.tenderService.InviteOrganization(c.Request.Context(), c.Param("tenderId"), invitation)
+	if err != nil {
+		writeError(c, h.log, err, invitationErrors)
+		return
+	}
+	c.JSON(http.StatusOK, invited)
+}
+
+func (h *TenderHandler) RevokeInvitation(c *gin.Context) {
+	if err := h.tenderService.RevokeInvitation(c.Request.Context(), c.Param("tenderId"), c.Param("organizationId")); err != nil {
+		writeError(c, h.log, err, invitationErrors)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+var invitationErrors = []errorCase{
+	{repository.ErrTenderNotFound, http.StatusNotFound, "Tender not found"},
+	{repository.ErrOrganizationNotFound, http.StatusNotFound, "Organization not found"},
+	{repository.ErrInvitationNotFound, http.StatusNotFound, "Organization is not invited to the tender"},
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (h *TenderHandler) GetTenderLots(c *gin.Context) {
+	lots, err := h.tenderService.GetTenderLots(c.Request.Context(), c.Param("tenderId"))
+	if err != nil {
+		writeError(c, h.log, err, lotErrors)
+		return
+	}
+	c.JSON(http.StatusOK, lots)
+}
+
+func (h *TenderHandler) CreateTenderLots(c *gin.Context) {
+	var lots []dto.LotDTO
+	if err := c.BindJSON(&lots); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": err.Error()})
+		return
+	}
+
+	created, err := h.tenderService.CreateTenderLots(c.Request.Context(), c.Param("tenderId"), lots)
+	if err != nil {
+		writeError(c, h.log, err, lotErrors)
+		return
+	}
+	c.JSON(http.StatusOK, created)
+}
+
+func (h *TenderHandler) CancelTenderLot(c *gin.Context) {
+	lot, err := h.tenderService.CancelTenderLot(c.Request.Context(), c.Param("tenderId"), c.Param("lotId"))
+	if err != nil {
+		writeError(c, h.log, err, lotErrors)
+		return
+	}
+	c.JSON(http.StatusOK, lot)
+}
+
+var lotErrors = []errorCase{
+	{repository.ErrTenderNotFound, http.StatusNotFound, "Tender not found"},
+	{repository.ErrLotNotFound, http.StatusNotFound, "Lot not found"},
+	{repository.ErrInvalidLot, http.StatusBadRequest, "Lot name is required"},
+	{repository.ErrLotsLocked, http.StatusConflict, "Lots can only be added before the tender is published"},
+	{repository.ErrLotNotOpen, http.StatusConflict, "Lot is already awarded or cancelled"},
+	{repository.ErrLastOpenLot, http.StatusConflict, "The last open lot can only be cancelled after the tender is published"},
+	{repository.ErrTenderCloseFailed, http.StatusInternalServerError, "Failed to close the tender"},
+}
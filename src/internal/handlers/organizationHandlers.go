@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/repository"
+	"git.codenrock.com/avito/internal/services"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type OrganizationHandler struct {
+	log                 *slog.Logger
+	organizationService *services.OrganizationService
+}
+
+func NewOrganizationHandler(log *slog.Logger, organizationService *services.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{
+		log:                 log,
+		organizationService: organizationService,
+	}
+}
+
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	var org dto.OrganizationDTO
+	if err := c.ShouldBindJSON(&org); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": err.Error()})
+		return
+	}
+
+	created, err := h.organizationService.CreateOrganization(c.Request.Context(), org)
+	if err != nil {
+		writeError(c, h.log, err, organizationErrors)
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+func (h *OrganizationHandler) GetOrganizations(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "5"))
+	if err != nil || limit < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid limit"})
+		return
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid offset"})
+		return
+	}
+
+	organizations, err := h.organizationService.GetOrganizations(c.Request.Context(), limit, offset)
+	if err != nil {
+		writeError(c, h.log, err, organizationErrors)
+		return
+	}
+	c.JSON(http.StatusOK, organizations)
+}
+
+func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
+	org, err := h.organizationService.GetOrganization(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeError(c, h.log, err, organizationErrors)
+		return
+	}
+	c.JSON(http.StatusOK, org)
+}
+
+func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
+	var data dto.UpdateOrganizationDTO
+	if err := c.ShouldBindJSON(&data); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": err.Error()})
+		return
+	}
+
+	org, err := h.organizationService.UpdateOrganization(c.Request.Context(), c.Param("id"), data)
+	if err != nil {
+		writeError(c, h.log, err, organizationErrors)
+		return
+	}
+	c.JSON(http.StatusOK, org)
+}
+
+func (h *OrganizationHandler) DeleteOrganization(c *gin.Context) {
+	if err := h.organizationService.DeleteOrganization(c.Request.Context(), c.Param("id")); err != nil {
+		writeError(c, h.log, err, organizationErrors)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *OrganizationHandler) GetResponsibles(c *gin.Context) {
+	users, err := h.organizationService.GetResponsibles(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeError(c, h.log, err, organizationErrors)
+		return
+	}
+	c.JSON(http.StatusOK, users)
+}
+
+func (h *OrganizationHandler) AddResponsible(c *gin.Context) {
+	var data dto.AddResponsibleDTO
+	if err := c.ShouldBindJSON(&data); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": err.Error()})
+		return
+	}
+
+	user, err := h.organizationService.AddResponsible(c.Request.Context(), c.Param("id"), data)
+	if err != nil {
+		writeError(c, h.log, err, organizationErrors)
+		return
+	}
+	c.JSON(http.StatusCreated, user)
+}
+
+func (h *OrganizationHandler) RemoveResponsible(c *gin.Context) {
+	if err := h.organizationService.RemoveResponsible(c.Request.Context(), c.Param("id"), c.Param("userId")); err != nil {
+		writeError(c, h.log, err, organizationErrors)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+var organizationErrors = []errorCase{
+	{repository.ErrOrganizationNotFound, http.StatusNotFound, "Organization not found"},
+	{repository.ErrUserNotFound, http.StatusNotFound, "Employee not found"},
+	{repository.ErrResponsibleNotFound, http.StatusNotFound, "Employee is not responsible for the organization"},
+	{repository.ErrResponsibleAlreadyExists, http.StatusConflict, "Employee is already responsible for the organization"},
+	{repository.ErrEntityInUse, http.StatusConflict, "Organization still has tenders or bids"},
+	{repository.ErrInvalidOrganizationType, http.StatusBadRequest, "Invalid organization type"},
+	{repository.ErrUsernameFieldEmpty, http.StatusBadRequest, "user_id or username is required"},
+}
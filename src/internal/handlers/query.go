@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"fmt"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxListLimit caps the page size of listings.
+const maxListLimit = 50
+
+// parseListQuery reads the shared listing parameters: q, status, organization_id,
+// creator, service_type, created_from/created_to, updated_from/updated_to,
+// sort=field,-field, limit (1 to maxListLimit, 5 by default), offset and the after
+// cursor. Which of them a listing supports is checked by the repository.
+func parseListQuery(c *gin.Context) (dto.ListQueryDTO, error) {
+	q := dto.ListQueryDTO{
+		Query:        strings.TrimSpace(c.Query("q")),
+		Statuses:     queryList(c, "status"),
+		Creator:      c.Query("creator"),
+		ServiceTypes: queryList(c, "service_type"),
+	}
+
+	var err error
+	if q.Limit, err = strconv.Atoi(c.DefaultQuery("limit", "5")); err != nil || q.Limit < 1 || q.Limit > maxListLimit {
+		return dto.ListQueryDTO{}, fmt.Errorf("invalid limit")
+	}
+	if q.Offset, err = strconv.Atoi(c.DefaultQuery("offset", "0")); err != nil || q.Offset < 0 {
+		return dto.ListQueryDTO{}, fmt.Errorf("invalid offset")
+	}
+
+	if raw := c.Query("after"); raw != "" {
+		after, err := dto.DecodeCursor(raw)
+		if err != nil {
+			return dto.ListQueryDTO{}, fmt.Errorf("invalid after")
+		}
+		q.After = &after
+		if q.Offset > 0 {
+			return dto.ListQueryDTO{}, fmt.Errorf("offset cannot be combined with after")
+		}
+	}
+
+	if raw := c.Query("organization_id"); raw != "" {
+		if q.OrganizationID, err = uuid.Parse(raw); err != nil {
+			return dto.ListQueryDTO{}, fmt.Errorf("invalid organization_id")
+		}
+	}
+
+	for key, target := range map[string]**time.Time{
+		"created_from": &q.CreatedFrom,
+		"created_to":   &q.CreatedTo,
+		"updated_from": &q.UpdatedFrom,
+		"updated_to":   &q.UpdatedTo,
+	} {
+		if *target, err = queryTime(c, key); err != nil {
+			return dto.ListQueryDTO{}, fmt.Errorf("invalid %s", key)
+		}
+	}
+
+	for _, field := range queryList(c, "sort") {
+		desc := strings.HasPrefix(field, "-")
+		field = strings.TrimPrefix(field, "-")
+		if field == "" {
+			return dto.ListQueryDTO{}, fmt.Errorf("invalid sort")
+		}
+		q.Sort = append(q.Sort, dto.SortField{Field: field, Desc: desc})
+	}
+
+	return q, nil
+}
+
+// writePage responds with the items of a page. The body stays a plain array as in the
+// API specification; the total number of matching rows and the cursor of the next
+// page are sent in the X-Total-Count and X-Next-Cursor headers.
+func writePage[T any](c *gin.Context, page dto.PageDTO[T]) {
+	c.Header("X-Total-Count", strconv.Itoa(page.Total))
+	if page.NextCursor != "" {
+		c.Header("X-Next-Cursor", page.NextCursor)
+	}
+	c.JSON(http.StatusOK, page.Items)
+}
+
+// queryList returns the values of a repeated or comma separated query parameter.
+func queryList(c *gin.Context, key string) []string {
+	var values []string
+	for _, raw := range c.QueryArray(key) {
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// queryTime parses an optional RFC 3339 timestamp or a plain date from the query.
+func queryTime(c *gin.Context, key string) (*time.Time, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		t, err = time.Parse(time.DateOnly, raw)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &t, nil
+}
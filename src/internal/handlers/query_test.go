@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"git.codenrock.com/avito/internal/domain/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseListQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	org := uuid.New()
+	cursor := dto.Cursor{CreatedAt: time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC), ID: uuid.New()}
+	from := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 9, 2, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		query   string
+		want    dto.ListQueryDTO
+		wantErr string
+	}{
+		{
+			name:  "defaults",
+			query: "",
+			want:  dto.ListQueryDTO{Limit: 5},
+		},
+		{
+			name:  "lists and sort",
+			query: "?q=+pipes+&status=Created,Published&status=Closed&service_type=Delivery&sort=name,-created_at&limit=10&offset=20",
+			want: dto.ListQueryDTO{
+				Query:        "pipes",
+				Statuses:     []string{"Created", "Published", "Closed"},
+				ServiceTypes: []string{"Delivery"},
+				Sort:         []dto.SortField{{Field: "name"}, {Field: "created_at", Desc: true}},
+				Limit:        10,
+				Offset:       20,
+			},
+		},
+		{
+			name:  "organization, creator and dates",
+			query: "?organization_id=" + org.String() + "&creator=user1&created_from=2024-09-01&created_to=2024-09-02T10:30:00Z",
+			want: dto.ListQueryDTO{
+				OrganizationID: org,
+				Creator:        "user1",
+				CreatedFrom:    &from,
+				CreatedTo:      &to,
+				Limit:          5,
+			},
+		},
+		{
+			name:  "cursor",
+			query: "?after=" + cursor.Encode(),
+			want:  dto.ListQueryDTO{Limit: 5, After: &cursor},
+		},
+		{name: "maximum limit", query: "?limit=50", want: dto.ListQueryDTO{Limit: 50}},
+		{name: "negative limit", query: "?limit=-1", wantErr: "invalid limit"},
+		{name: "zero limit", query: "?limit=0", wantErr: "invalid limit"},
+		{name: "limit above maximum", query: "?limit=51", wantErr: "invalid limit"},
+		{name: "limit not a number", query: "?limit=ten", wantErr: "invalid limit"},
+		{name: "negative offset", query: "?offset=-1", wantErr: "invalid offset"},
+		{name: "bad cursor", query: "?after=abc", wantErr: "invalid after"},
+		{name: "cursor with offset", query: "?offset=5&after=" + cursor.Encode(), wantErr: "offset cannot be combined with after"},
+		{name: "bad organization", query: "?organization_id=42", wantErr: "invalid organization_id"},
+		{name: "bad date", query: "?updated_to=tomorrow", wantErr: "invalid updated_to"},
+		{name: "empty sort field", query: "?sort=-", wantErr: "invalid sort"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("GET", "/api/tenders"+tt.query, nil)
+
+			got, err := parseListQuery(c)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("parseListQuery() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseListQuery() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseListQuery() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
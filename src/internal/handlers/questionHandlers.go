@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (h *TenderHandler) GetTenderQuestions(c *gin.Context) {
+	q, err := parseListQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": err.Error()})
+		return
+	}
+
+	questions, err := h.tenderService.GetTenderQuestions(c.Request.Context(), c.Param("tenderId"), q)
+	if err != nil {
+		writeError(c, h.log, err, questionErrors)
+		return
+	}
+	writePage(c, questions)
+}
+
+func (h *TenderHandler) AskTenderQuestion(c *gin.Context) {
+	var question dto.QuestionDTO
+	if err := c.BindJSON(&question); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": err.Error()})
+		return
+	}
+
+	created, err := h.tenderService.AskTenderQuestion(c.Request.Context(), c.Param("tenderId"), question)
+	if err != nil {
+		writeError(c, h.log, err, questionErrors)
+		return
+	}
+	c.JSON(http.StatusOK, created)
+}
+
+func (h *TenderHandler) AnswerTenderQuestion(c *gin.Context) {
+	var answer dto.AnswerDTO
+	if err := c.BindJSON(&answer); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": err.Error()})
+		return
+	}
+
+	answered, err := h.tenderService.AnswerTenderQuestion(c.Request.Context(), c.Param("tenderId"), c.Param("questionId"), answer)
+	if err != nil {
+		writeError(c, h.log, err, questionErrors)
+		return
+	}
+	c.JSON(http.StatusOK, answered)
+}
+
+var questionErrors = []errorCase{
+	{repository.ErrTenderNotFound, http.StatusNotFound, "Tender not found"},
+	{repository.ErrQuestionNotFound, http.StatusNotFound, "Question not found"},
+	{repository.ErrInvalidQuestion, http.StatusBadRequest, "Question or answer is empty or too long"},
+	{repository.ErrInvalidListQuery, http.StatusBadRequest, "Unsupported filter or sort field"},
+	{repository.ErrTenderNotPublished, http.StatusConflict, "Questions can only be asked on a published tender"},
+}
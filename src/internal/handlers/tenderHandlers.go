@@ -2,8 +2,12 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"git.codenrock.com/avito/internal/auth"
+	"git.codenrock.com/avito/internal/authz"
 	"git.codenrock.com/avito/internal/domain/dto"
 	"git.codenrock.com/avito/internal/domain/models"
+	"git.codenrock.com/avito/internal/repository"
 	"git.codenrock.com/avito/internal/services"
 	"github.com/gin-gonic/gin"
 	"log/slog"
@@ -12,13 +16,31 @@ import (
 )
 
 type TenderService interface {
-	GetTenders(ctx context.Context, serviceTypes []string, limit, offset int) ([]dto.TenderResponseDTO, error)
+	GetTenders(ctx context.Context, q dto.ListQueryDTO) (dto.PageDTO[dto.TenderResponseDTO], error)
 	CreateTender(ctx context.Context, tender *models.Tender) error
-	GetUserTenders(ctx context.Context, username string, limit, offset int) ([]dto.TenderResponseDTO, error)
-	GetTenderStatus(ctx context.Context, tenderID, username string) (string, error)
-	UpdateTenderStatus(ctx context.Context, tenderID, newStatus, username string) (dto.TenderResponseDTO, error)
-	UpdateTenderInfo(ctx context.Context, tenderID string, updatedData dto.UpdateTenderDTO, username string) (dto.TenderResponseDTO, error)
-	RollbackTenderVersion(ctx context.Context, tenderID string, version int, username string) (dto.TenderResponseDTO, error)
+	GetUserTenders(ctx context.Context, q dto.ListQueryDTO) (dto.PageDTO[dto.TenderResponseDTO], error)
+	GetTenderStatus(ctx context.Context, tenderID string) (string, int, error)
+	UpdateTenderStatus(ctx context.Context, tenderID, newStatus string, expectedVersion int) (dto.TenderResponseDTO, error)
+	GetTenderStatusHistory(ctx context.Context, tenderID string) ([]dto.TenderStatusChangeDTO, error)
+	GetTenderVersions(ctx context.Context, tenderID string) ([]dto.VersionDTO, error)
+	GetTenderVersion(ctx context.Context, tenderID string, version int) (dto.VersionDTO, error)
+	GetTenderDiff(ctx context.Context, tenderID string, from, to int) (dto.VersionDiffDTO, error)
+	UpdateTenderInfo(ctx context.Context, tenderID string, updatedData dto.UpdateTenderDTO, expectedVersion int) (dto.TenderResponseDTO, error)
+	RollbackTenderVersion(ctx context.Context, tenderID string, version int, expectedVersion int) (dto.TenderResponseDTO, error)
+	GetTenderLots(ctx context.Context, tenderID string) ([]dto.TenderLotDTO, error)
+	CreateTenderLots(ctx context.Context, tenderID string, lots []dto.LotDTO) ([]dto.TenderLotDTO, error)
+	CancelTenderLot(ctx context.Context, tenderID, lotID string) (dto.TenderLotDTO, error)
+	GetTenderCriteria(ctx context.Context, tenderID string) ([]dto.TenderCriterionDTO, error)
+	ReplaceTenderCriteria(ctx context.Context, tenderID string, criteria []dto.CriterionDTO) ([]dto.TenderCriterionDTO, error)
+	GetTenderRanking(ctx context.Context, tenderID string) ([]dto.BidRankingDTO, error)
+	GetTenderQuestions(ctx context.Context, tenderID string, q dto.ListQueryDTO) (dto.PageDTO[dto.TenderQuestionDTO], error)
+	AskTenderQuestion(ctx context.Context, tenderID string, question dto.QuestionDTO) (dto.TenderQuestionDTO, error)
+	AnswerTenderQuestion(ctx context.Context, tenderID, questionID string, answer dto.AnswerDTO) (dto.TenderQuestionDTO, error)
+	GetTenderInvitations(ctx context.Context, tenderID string) ([]dto.TenderInvitationDTO, error)
+	InviteOrganization(ctx context.Context, tenderID string, invitation dto.InvitationDTO) (dto.TenderInvitationDTO, error)
+	RevokeInvitation(ctx context.Context, tenderID, organizationID string) error
+	GetTenderAuction(ctx context.Context, tenderID string) (dto.TenderAuctionDTO, error)
+	ConfigureAuction(ctx context.Context, tenderID string, auction dto.AuctionDTO) (dto.TenderAuctionDTO, error)
 }
 
 type TenderHandler struct {
@@ -34,19 +56,23 @@ func NewTenderHandler(log *slog.Logger, tenderService *services.TenderService) *
 }
 
 func (h *TenderHandler) GetTenders(c *gin.Context) {
-	limit := c.DefaultQuery("limit", "5")
-	offset := c.DefaultQuery("offset", "0")
-	serviceTypes := c.QueryArray("service_type")
-
-	limitInt, _ := strconv.Atoi(limit)
-	offsetInt, _ := strconv.Atoi(offset)
+	q, err := parseListQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": err.Error()})
+		return
+	}
 
-	tenders, err := h.tenderService.GetTenders(c.Request.Context(), serviceTypes, limitInt, offsetInt)
+	tenders, err := h.tenderService.GetTenders(c.Request.Context(), q)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"reason": err.Error()})
+		switch {
+		case errors.Is(err, repository.ErrInvalidListQuery):
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "Unsupported filter or sort field"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"reason": err.Error()})
+		}
 		return
 	}
-	c.JSON(http.StatusOK, tenders)
+	writePage(c, tenders)
 }
 
 func (h *TenderHandler) CreateTender(c *gin.Context) {
@@ -58,66 +84,180 @@ func (h *TenderHandler) CreateTender(c *gin.Context) {
 
 	tender, err := h.tenderService.CreateTender(c.Request.Context(), newTender)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, err)
+		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		case errors.Is(err, authz.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, auth.ErrIdentityMismatch), errors.Is(err, repository.ErrNoAccessRights):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrInvalidDeadline):
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid tender deadlines"})
+		case errors.Is(err, repository.ErrInvalidLot):
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "Lot name is required"})
+		case errors.Is(err, repository.ErrInvalidAmount), errors.Is(err, repository.ErrInvalidBudget), errors.Is(err, repository.ErrInvalidCurrency):
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid tender budget"})
+		case errors.Is(err, repository.ErrInvalidVisibility):
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "Visibility must be Public or InviteOnly"})
+		case errors.Is(err, repository.ErrSealedWithoutDeadline):
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "A sealed tender needs a submission deadline"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"reason": err.Error()})
+		}
 		return
 	}
 	c.JSON(http.StatusOK, tender)
 }
 
 func (h *TenderHandler) GetTendersByUsername(c *gin.Context) {
-	username := c.Query("username")
-	limit := c.DefaultQuery("limit", "5")
-	offset := c.DefaultQuery("offset", "0")
-
-	limitInt, _ := strconv.Atoi(limit)
-	offsetInt, _ := strconv.Atoi(offset)
+	q, err := parseListQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": err.Error()})
+		return
+	}
 
-	tenders, err := h.tenderService.GetUserTenders(c.Request.Context(), username, limitInt, offsetInt)
+	tenders, err := h.tenderService.GetUserTenders(c.Request.Context(), q)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"reason": err.Error()})
+		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		case errors.Is(err, authz.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrInvalidListQuery):
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "Unsupported filter or sort field"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"reason": err.Error()})
+		}
 		return
 	}
-	c.JSON(http.StatusOK, tenders)
+	writePage(c, tenders)
 }
 
 func (h *TenderHandler) GetTenderStatus(c *gin.Context) {
 	tenderID := c.Param("tenderId")
-	username := c.Query("username")
-	status, err := h.tenderService.GetTenderStatus(c.Request.Context(), tenderID, username)
+	status, version, err := h.tenderService.GetTenderStatus(c.Request.Context(), tenderID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"reason": err.Error()})
+		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		case errors.Is(err, authz.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrTenderNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"reason": "Tender not found"})
+		case errors.Is(err, repository.ErrNoAccessRights):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"reason": err.Error()})
+		}
 		return
 	}
+	setETag(c, version)
 	c.JSON(http.StatusOK, status)
 }
 
 func (h *TenderHandler) UpdateTenderStatus(c *gin.Context) {
 	tenderID := c.Param("tenderId")
 	newStatus := c.Query("status")
-	username := c.Query("username")
+	expected, err := expectedVersion(c, 0)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid If-Match header"})
+		return
+	}
 
-	tender, err := h.tenderService.UpdateTenderStatus(c.Request.Context(), tenderID, newStatus, username)
+	tender, err := h.tenderService.UpdateTenderStatus(c.Request.Context(), tenderID, newStatus, expected)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"reason": err.Error()})
+		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		case errors.Is(err, authz.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrVersionConflict):
+			c.JSON(http.StatusPreconditionFailed, gin.H{"reason": "Tender was modified by another request"})
+		case errors.Is(err, repository.ErrInvalidTenderStatus):
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid tender status"})
+		case errors.Is(err, repository.ErrTenderNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"reason": "Tender not found"})
+		case errors.Is(err, repository.ErrUserIsNotCreatorOrTenderWasNotFound):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrIllegalTenderTransition):
+			c.JSON(http.StatusConflict, gin.H{"reason": "Tender status transition is not allowed"})
+		case errors.Is(err, repository.ErrSubmissionClosed):
+			c.JSON(http.StatusConflict, gin.H{"reason": "Tender submission deadline has passed"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"reason": err.Error()})
+		}
 		return
 	}
+	setETag(c, tender.Version)
 	c.JSON(http.StatusOK, tender)
 }
 
+func (h *TenderHandler) GetTenderStatusHistory(c *gin.Context) {
+	tenderID := c.Param("tenderId")
+
+	history, err := h.tenderService.GetTenderStatusHistory(c.Request.Context(), tenderID)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		case errors.Is(err, authz.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrTenderNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"reason": "Tender not found"})
+		case errors.Is(err, repository.ErrNoAccessRights):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"reason": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, history)
+}
+
 func (h *TenderHandler) UpdateTenderInfo(c *gin.Context) {
 	tenderID := c.Param("tenderId")
-	username := c.Query("username")
 	var updatedData dto.UpdateTenderDTO
 	if err := c.BindJSON(&updatedData); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"reason": err.Error()})
 		return
 	}
+	expected, err := expectedVersion(c, updatedData.ExpectedVersion)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid If-Match header"})
+		return
+	}
 
-	updatedTender, err := h.tenderService.UpdateTenderInfo(c.Request.Context(), tenderID, updatedData, username)
+	updatedTender, err := h.tenderService.UpdateTenderInfo(c.Request.Context(), tenderID, updatedData, expected)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"reason": err.Error()})
+		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		case errors.Is(err, authz.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrVersionConflict):
+			c.JSON(http.StatusPreconditionFailed, gin.H{"reason": "Tender was modified by another request"})
+		case errors.Is(err, repository.ErrTenderNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"reason": "Tender not found"})
+		case errors.Is(err, repository.ErrUserIsNotCreatorOrTenderWasNotFound):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrInvalidDeadline):
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid tender deadlines"})
+		case errors.Is(err, repository.ErrInvalidAmount), errors.Is(err, repository.ErrInvalidBudget), errors.Is(err, repository.ErrInvalidCurrency):
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid tender budget"})
+		case errors.Is(err, repository.ErrInvalidVisibility):
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "Visibility must be Public or InviteOnly"})
+		case errors.Is(err, repository.ErrSealedWithoutDeadline):
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "A sealed tender needs a submission deadline"})
+		case errors.Is(err, repository.ErrIllegalTenderTransition):
+			c.JSON(http.StatusConflict, gin.H{"reason": "Publication can only be scheduled for a tender in Created status"})
+		case errors.Is(err, repository.ErrSealingLocked):
+			c.JSON(http.StatusConflict, gin.H{"reason": "Sealing can only change before the tender is published"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"reason": err.Error()})
+		}
 		return
 	}
+	setETag(c, updatedTender.Version)
 	c.JSON(http.StatusOK, updatedTender)
 }
 
@@ -128,12 +268,120 @@ func (h *TenderHandler) RollbackTenderVersion(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный номер версии"})
 		return
 	}
-	username := c.Query("username")
+	expected, err := expectedVersion(c, 0)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid If-Match header"})
+		return
+	}
 
-	rolledBackTender, err := h.tenderService.RollbackTenderVersion(c.Request.Context(), tenderID, version, username)
+	rolledBackTender, err := h.tenderService.RollbackTenderVersion(c.Request.Context(), tenderID, version, expected)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		case errors.Is(err, authz.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrVersionConflict):
+			c.JSON(http.StatusPreconditionFailed, gin.H{"reason": "Tender was modified by another request"})
+		case errors.Is(err, repository.ErrTenderNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"reason": "Tender not found"})
+		case errors.Is(err, repository.ErrVersionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"reason": "Version not found"})
+		case errors.Is(err, repository.ErrUserIsNotCreatorOrTenderWasNotFound):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"reason": err.Error()})
+		}
 		return
 	}
+	setETag(c, rolledBackTender.Version)
 	c.JSON(http.StatusOK, rolledBackTender)
 }
+
+func (h *TenderHandler) GetTenderVersions(c *gin.Context) {
+	tenderID := c.Param("tenderId")
+
+	versions, err := h.tenderService.GetTenderVersions(c.Request.Context(), tenderID)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		case errors.Is(err, authz.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrTenderNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"reason": "Tender not found"})
+		case errors.Is(err, repository.ErrVersionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"reason": "Version not found"})
+		case errors.Is(err, repository.ErrNoAccessRights):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"reason": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, versions)
+}
+
+func (h *TenderHandler) GetTenderVersion(c *gin.Context) {
+	tenderID := c.Param("tenderId")
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid version format"})
+		return
+	}
+
+	tenderVersion, err := h.tenderService.GetTenderVersion(c.Request.Context(), tenderID, version)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		case errors.Is(err, authz.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrTenderNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"reason": "Tender not found"})
+		case errors.Is(err, repository.ErrVersionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"reason": "Version not found"})
+		case errors.Is(err, repository.ErrNoAccessRights):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"reason": err.Error()})
+		}
+		return
+	}
+	setETag(c, tenderVersion.Version)
+	c.JSON(http.StatusOK, tenderVersion)
+}
+
+func (h *TenderHandler) GetTenderDiff(c *gin.Context) {
+	tenderID := c.Param("tenderId")
+	from, err := strconv.Atoi(c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid from version"})
+		return
+	}
+	to, err := strconv.Atoi(c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid to version"})
+		return
+	}
+
+	diff, err := h.tenderService.GetTenderDiff(c.Request.Context(), tenderID, from, to)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrUnauthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"reason": "Authentication required"})
+		case errors.Is(err, authz.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		case errors.Is(err, repository.ErrTenderNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"reason": "Tender not found"})
+		case errors.Is(err, repository.ErrVersionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"reason": "Version not found"})
+		case errors.Is(err, repository.ErrNoAccessRights):
+			c.JSON(http.StatusForbidden, gin.H{"reason": "Insufficient permissions"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"reason": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, diff)
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (h *BidHandler) WithdrawBid(c *gin.Context) {
+	var withdrawal dto.WithdrawBidDTO
+	if err := c.BindJSON(&withdrawal); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": err.Error()})
+		return
+	}
+
+	expected, err := expectedVersion(c, 0)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid If-Match header"})
+		return
+	}
+
+	bid, err := h.bidService.WithdrawBid(c.Request.Context(), c.Param("id"), withdrawal, expected)
+	if err != nil {
+		writeError(c, h.log, err, withdrawalErrors)
+		return
+	}
+
+	setETag(c, bid.Version)
+	c.JSON(http.StatusOK, bid)
+}
+
+func (h *BidHandler) ResubmitBid(c *gin.Context) {
+	expected, err := expectedVersion(c, 0)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "Invalid If-Match header"})
+		return
+	}
+
+	bid, err := h.bidService.ResubmitBid(c.Request.Context(), c.Param("id"), expected)
+	if err != nil {
+		writeError(c, h.log, err, withdrawalErrors)
+		return
+	}
+
+	setETag(c, bid.Version)
+	c.JSON(http.StatusOK, bid)
+}
+
+var withdrawalErrors = []errorCase{
+	{repository.ErrVersionConflict, http.StatusPreconditionFailed, "Bid was modified by another request"},
+	{repository.ErrBidNotFound, http.StatusNotFound, "Bid not found"},
+	{repository.ErrTenderNotFound, http.StatusNotFound, "Bid not found"},
+	{repository.ErrInvalidWithdrawalReason, http.StatusBadRequest, "Withdrawal reason is required and must not exceed 1000 characters"},
+	{repository.ErrBidAlreadyDecided, http.StatusConflict, "Bid has already been decided"},
+	{repository.ErrIllegalBidTransition, http.StatusConflict, "Only published bids can be withdrawn and only withdrawn bids resubmitted"},
+	{repository.ErrSubmissionClosed, http.StatusConflict, "Submission deadline has passed"},
+}
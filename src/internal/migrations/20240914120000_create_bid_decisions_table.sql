@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE bid_decisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bid_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    decision VARCHAR(20) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_bid
+        FOREIGN KEY (bid_id)
+        REFERENCES bids(id) ON DELETE CASCADE,
+    CONSTRAINT fk_employee
+        FOREIGN KEY (user_id)
+        REFERENCES employee(id) ON DELETE CASCADE,
+    CONSTRAINT uq_bid_decisions_bid_user UNIQUE (bid_id, user_id)
+);
+
+-- +goose Down
+DROP TABLE bid_decisions;
@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+UPDATE tenders SET status = INITCAP(LOWER(status));
+ALTER TABLE tenders ALTER COLUMN status SET DEFAULT 'Created';
+UPDATE tender_versions SET status = INITCAP(LOWER(status));
+
+CREATE TABLE tender_status_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL,
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    actor_username VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_tender
+        FOREIGN KEY (tender_id)
+        REFERENCES tenders(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_tender_status_history_tender ON tender_status_history (tender_id, created_at);
+
+-- +goose Down
+DROP TABLE tender_status_history;
+ALTER TABLE tenders ALTER COLUMN status SET DEFAULT 'CREATED';
@@ -0,0 +1,41 @@
+-- +goose Up
+ALTER TABLE bids ADD COLUMN description TEXT;
+ALTER TABLE bids ADD COLUMN organization_id UUID REFERENCES organization(id);
+
+-- The old integer author ids cannot be mapped to employee or organization UUIDs, so
+-- the migration refuses to run over existing authors instead of discarding them.
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM bids WHERE author_id IS NOT NULL)
+        OR EXISTS (SELECT 1 FROM bids_versions WHERE author_id IS NOT NULL) THEN
+        RAISE EXCEPTION 'bids have integer author_id values that cannot be converted to UUID; migrate them to employee or organization ids first';
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+ALTER TABLE bids ALTER COLUMN author_id TYPE UUID USING NULL::UUID;
+ALTER TABLE bids_versions ALTER COLUMN author_id TYPE UUID USING NULL::UUID;
+
+UPDATE bids SET status = INITCAP(LOWER(status));
+ALTER TABLE bids ALTER COLUMN status SET DEFAULT 'Created';
+UPDATE bids_versions SET status = INITCAP(LOWER(status));
+
+-- +goose Down
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM bids WHERE author_id IS NOT NULL)
+        OR EXISTS (SELECT 1 FROM bids_versions WHERE author_id IS NOT NULL) THEN
+        RAISE EXCEPTION 'bids have UUID author_id values that cannot be converted back to integers';
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+ALTER TABLE bids ALTER COLUMN status SET DEFAULT 'CREATED';
+ALTER TABLE bids_versions ALTER COLUMN author_id TYPE INTEGER USING NULL::INTEGER;
+ALTER TABLE bids ALTER COLUMN author_id TYPE INTEGER USING NULL::INTEGER;
+ALTER TABLE bids DROP COLUMN organization_id;
+ALTER TABLE bids DROP COLUMN description;
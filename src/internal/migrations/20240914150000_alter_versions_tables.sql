@@ -0,0 +1,29 @@
+-- +goose Up
+ALTER TABLE tenders ADD COLUMN updated_by VARCHAR(100);
+UPDATE tenders SET updated_by = creator_username;
+
+ALTER TABLE bids ADD COLUMN updated_by VARCHAR(100);
+
+ALTER TABLE tender_versions RENAME COLUMN title TO name;
+ALTER TABLE tender_versions ADD COLUMN service_type VARCHAR(255);
+ALTER TABLE tender_versions ADD COLUMN edited_by VARCHAR(100);
+ALTER TABLE tender_versions ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+ALTER TABLE tender_versions ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+ALTER TABLE tender_versions ADD CONSTRAINT uq_tender_versions_tender_version UNIQUE (tender_id, version);
+
+ALTER TABLE bids_versions ADD COLUMN description TEXT;
+ALTER TABLE bids_versions ADD COLUMN edited_by VARCHAR(100);
+ALTER TABLE bids_versions ADD CONSTRAINT uq_bids_versions_bid_version UNIQUE (bid_id, version);
+
+-- +goose Down
+ALTER TABLE bids_versions DROP CONSTRAINT uq_bids_versions_bid_version;
+ALTER TABLE bids_versions DROP COLUMN edited_by;
+ALTER TABLE bids_versions DROP COLUMN description;
+
+ALTER TABLE tender_versions DROP CONSTRAINT uq_tender_versions_tender_version;
+ALTER TABLE tender_versions DROP COLUMN edited_by;
+ALTER TABLE tender_versions DROP COLUMN service_type;
+ALTER TABLE tender_versions RENAME COLUMN name TO title;
+
+ALTER TABLE bids DROP COLUMN updated_by;
+ALTER TABLE tenders DROP COLUMN updated_by;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Hashes are bcrypt; they can be set with pgcrypto: crypt('password', gen_salt('bf')).
+ALTER TABLE employee ADD COLUMN password_hash VARCHAR(72);
+
+-- +goose Down
+ALTER TABLE employee DROP COLUMN password_hash;
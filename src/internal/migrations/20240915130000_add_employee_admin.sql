@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE employee ADD COLUMN is_admin BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE employee DROP COLUMN is_admin;
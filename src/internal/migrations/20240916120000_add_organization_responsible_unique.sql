@@ -0,0 +1,12 @@
+-- +goose Up
+DELETE FROM organization_responsible a
+    USING organization_responsible b
+    WHERE a.organization_id = b.organization_id
+      AND a.user_id = b.user_id
+      AND a.id > b.id;
+
+ALTER TABLE organization_responsible
+    ADD CONSTRAINT organization_responsible_organization_user_key UNIQUE (organization_id, user_id);
+
+-- +goose Down
+ALTER TABLE organization_responsible DROP CONSTRAINT organization_responsible_organization_user_key;
@@ -0,0 +1,43 @@
+-- +goose Up
+ALTER TABLE bid_reviews
+    ADD COLUMN bid_id UUID REFERENCES bids(id) ON DELETE CASCADE,
+    ADD COLUMN reviewer_id UUID REFERENCES employee(id) ON DELETE SET NULL,
+    ADD COLUMN organization_id UUID REFERENCES organization(id) ON DELETE CASCADE;
+
+-- Feedback written by the old code went to bid_feedback, which only existed in
+-- hand-made databases. Its rows are linked, so they are carried over as reviews from
+-- the organization that owns the tender. Older bid_reviews rows carry no link at all;
+-- rather than dropping them, the migration stops and leaves them to the operator.
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF to_regclass('bid_feedback') IS NOT NULL THEN
+        EXECUTE 'INSERT INTO bid_reviews (description, bid_id, reviewer_id, organization_id)
+            SELECT f.feedback, f.bid_id, f.author_id, t.organization_id
+            FROM bid_feedback f
+            JOIN bids b ON b.id = f.bid_id
+            JOIN tenders t ON t.id = b.tender_id';
+    END IF;
+
+    IF EXISTS (SELECT 1 FROM bid_reviews WHERE bid_id IS NULL) THEN
+        RAISE EXCEPTION 'bid_reviews has rows not linked to a bid; set bid_id and organization_id on them or remove them before migrating';
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+ALTER TABLE bid_reviews
+    ALTER COLUMN bid_id SET NOT NULL,
+    ALTER COLUMN organization_id SET NOT NULL;
+
+CREATE INDEX idx_bid_reviews_bid_id ON bid_reviews (bid_id);
+CREATE INDEX idx_bid_reviews_organization_id ON bid_reviews (organization_id);
+
+-- +goose Down
+DROP INDEX idx_bid_reviews_organization_id;
+DROP INDEX idx_bid_reviews_bid_id;
+
+ALTER TABLE bid_reviews
+    DROP COLUMN organization_id,
+    DROP COLUMN reviewer_id,
+    DROP COLUMN bid_id;
@@ -0,0 +1,16 @@
+-- +goose Up
+ALTER TABLE tenders
+    ADD COLUMN submission_deadline TIMESTAMPTZ,
+    ADD COLUMN decision_deadline TIMESTAMPTZ,
+    ADD CONSTRAINT tenders_decision_after_submission
+        CHECK (decision_deadline IS NULL OR (submission_deadline IS NOT NULL AND decision_deadline >= submission_deadline));
+
+CREATE INDEX idx_tenders_submission_deadline ON tenders (submission_deadline) WHERE status = 'Published';
+
+-- +goose Down
+DROP INDEX idx_tenders_submission_deadline;
+
+ALTER TABLE tenders
+    DROP CONSTRAINT tenders_decision_after_submission,
+    DROP COLUMN decision_deadline,
+    DROP COLUMN submission_deadline;
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE tenders ADD COLUMN publish_at TIMESTAMPTZ;
+
+CREATE INDEX idx_tenders_publish_at ON tenders (publish_at) WHERE status = 'Created';
+
+-- +goose Down
+DROP INDEX idx_tenders_publish_at;
+
+ALTER TABLE tenders DROP COLUMN publish_at;
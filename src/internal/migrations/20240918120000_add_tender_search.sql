@@ -0,0 +1,16 @@
+-- +goose Up
+ALTER TABLE tenders ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', COALESCE(name, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(name, '')), 'A') ||
+    setweight(to_tsvector('russian', COALESCE(description, '')), 'B') ||
+    setweight(to_tsvector('english', COALESCE(description, '')), 'B')
+) STORED;
+
+CREATE INDEX idx_tenders_search_vector ON tenders USING GIN (search_vector);
+CREATE INDEX idx_tenders_published_created_at ON tenders (created_at DESC) WHERE status = 'Published';
+
+-- +goose Down
+DROP INDEX idx_tenders_published_created_at;
+DROP INDEX idx_tenders_search_vector;
+
+ALTER TABLE tenders DROP COLUMN search_vector;
@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE INDEX idx_tenders_creator_created_at ON tenders (creator_username, created_at, id);
+CREATE INDEX idx_bids_tender_created_at ON bids (tender_id, created_at, id);
+CREATE INDEX idx_bids_author_created_at ON bids (author_id, created_at, id);
+CREATE INDEX idx_bid_reviews_organization_created_at ON bid_reviews (organization_id, created_at, id);
+
+-- +goose Down
+DROP INDEX idx_bid_reviews_organization_created_at;
+DROP INDEX idx_bids_author_created_at;
+DROP INDEX idx_bids_tender_created_at;
+DROP INDEX idx_tenders_creator_created_at;
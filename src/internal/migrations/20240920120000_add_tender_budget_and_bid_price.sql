@@ -0,0 +1,44 @@
+-- +goose Up
+ALTER TABLE tenders
+    ADD COLUMN budget_min NUMERIC(18, 2),
+    ADD COLUMN budget_max NUMERIC(18, 2),
+    ADD COLUMN currency CHAR(3),
+    ADD COLUMN vat_included BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN cap_at_budget BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD CONSTRAINT tenders_budget_valid
+        CHECK ((budget_min IS NULL OR budget_min >= 0) AND (budget_max IS NULL OR budget_max >= 0)
+            AND (budget_min IS NULL OR budget_max IS NULL OR budget_min <= budget_max)),
+    ADD CONSTRAINT tenders_budget_currency
+        CHECK (currency IS NOT NULL OR (budget_min IS NULL AND budget_max IS NULL));
+
+ALTER TABLE bids
+    ADD COLUMN price NUMERIC(18, 2),
+    ADD COLUMN currency CHAR(3),
+    ADD CONSTRAINT bids_price_valid CHECK (price IS NULL OR (price >= 0 AND currency IS NOT NULL));
+
+CREATE TABLE bid_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bid_id UUID NOT NULL REFERENCES bids (id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    quantity NUMERIC(18, 3) NOT NULL CHECK (quantity > 0),
+    unit_price NUMERIC(18, 2) NOT NULL CHECK (unit_price >= 0),
+    UNIQUE (bid_id, position)
+);
+
+-- +goose Down
+DROP TABLE bid_items;
+
+ALTER TABLE bids
+    DROP CONSTRAINT bids_price_valid,
+    DROP COLUMN currency,
+    DROP COLUMN price;
+
+ALTER TABLE tenders
+    DROP CONSTRAINT tenders_budget_currency,
+    DROP CONSTRAINT tenders_budget_valid,
+    DROP COLUMN cap_at_budget,
+    DROP COLUMN vat_included,
+    DROP COLUMN currency,
+    DROP COLUMN budget_max,
+    DROP COLUMN budget_min;
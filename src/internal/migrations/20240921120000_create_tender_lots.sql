@@ -0,0 +1,26 @@
+-- +goose Up
+CREATE TABLE tender_lots (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tenders (id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(500),
+    status VARCHAR(20) NOT NULL DEFAULT 'Open',
+    awarded_bid_id UUID REFERENCES bids (id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (tender_id, position),
+    CONSTRAINT tender_lots_awarded_bid CHECK ((status = 'Awarded') = (awarded_bid_id IS NOT NULL))
+);
+
+CREATE TABLE bid_lots (
+    bid_id UUID NOT NULL REFERENCES bids (id) ON DELETE CASCADE,
+    lot_id UUID NOT NULL REFERENCES tender_lots (id) ON DELETE CASCADE,
+    PRIMARY KEY (bid_id, lot_id)
+);
+
+CREATE INDEX idx_bid_lots_lot_id ON bid_lots (lot_id);
+
+-- +goose Down
+DROP TABLE bid_lots;
+DROP TABLE tender_lots;
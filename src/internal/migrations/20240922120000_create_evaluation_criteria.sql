@@ -0,0 +1,27 @@
+-- +goose Up
+CREATE TABLE tender_criteria (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tenders (id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    weight NUMERIC(5, 2) NOT NULL CHECK (weight > 0 AND weight <= 100),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (tender_id, position)
+);
+
+CREATE TABLE bid_scores (
+    bid_id UUID NOT NULL REFERENCES bids (id) ON DELETE CASCADE,
+    criterion_id UUID NOT NULL REFERENCES tender_criteria (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES employee (id) ON DELETE CASCADE,
+    score NUMERIC(5, 2) NOT NULL CHECK (score >= 0 AND score <= 100),
+    comment VARCHAR(500),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (bid_id, criterion_id, user_id)
+);
+
+CREATE INDEX idx_bid_scores_criterion_id ON bid_scores (criterion_id);
+
+-- +goose Down
+DROP TABLE bid_scores;
+DROP TABLE tender_criteria;
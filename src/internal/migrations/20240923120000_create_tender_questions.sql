@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE tender_questions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tenders (id) ON DELETE CASCADE,
+    author_id UUID NOT NULL REFERENCES employee (id) ON DELETE CASCADE,
+    question VARCHAR(1000) NOT NULL,
+    answer VARCHAR(2000),
+    answered_by UUID REFERENCES employee (id) ON DELETE SET NULL,
+    answer_public BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    answered_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_tender_questions_tender_created_at ON tender_questions (tender_id, created_at, id);
+
+-- +goose Down
+DROP TABLE tender_questions;
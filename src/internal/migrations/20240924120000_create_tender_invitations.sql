@@ -0,0 +1,19 @@
+-- +goose Up
+ALTER TABLE tenders
+    ADD COLUMN visibility VARCHAR(20) NOT NULL DEFAULT 'Public';
+
+CREATE TABLE tender_invitations (
+    tender_id UUID NOT NULL REFERENCES tenders (id) ON DELETE CASCADE,
+    organization_id UUID NOT NULL REFERENCES organization (id) ON DELETE CASCADE,
+    invited_by VARCHAR(50),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tender_id, organization_id)
+);
+
+CREATE INDEX idx_tender_invitations_organization_id ON tender_invitations (organization_id);
+
+-- +goose Down
+DROP TABLE tender_invitations;
+
+ALTER TABLE tenders
+    DROP COLUMN visibility;
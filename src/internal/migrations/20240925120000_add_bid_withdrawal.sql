@@ -0,0 +1,15 @@
+-- +goose Up
+ALTER TABLE bids
+    ADD COLUMN withdrawal_reason TEXT,
+    ADD COLUMN withdrawn_at TIMESTAMPTZ;
+
+ALTER TABLE bids_versions
+    ADD COLUMN withdrawal_reason TEXT;
+
+-- +goose Down
+ALTER TABLE bids_versions
+    DROP COLUMN withdrawal_reason;
+
+ALTER TABLE bids
+    DROP COLUMN withdrawn_at,
+    DROP COLUMN withdrawal_reason;
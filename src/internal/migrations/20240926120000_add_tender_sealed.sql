@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE tenders
+    ADD COLUMN sealed BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE tenders
+    DROP COLUMN sealed;
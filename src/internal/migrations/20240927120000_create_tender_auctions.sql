@@ -0,0 +1,30 @@
+-- +goose Up
+CREATE TABLE tender_auctions (
+    tender_id UUID PRIMARY KEY REFERENCES tenders (id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'Open',
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    min_decrement NUMERIC(18, 2) NOT NULL CHECK (min_decrement > 0),
+    extension_window_minutes INT NOT NULL DEFAULT 0,
+    extension_minutes INT NOT NULL DEFAULT 0,
+    winning_bid_id UUID REFERENCES bids (id) ON DELETE SET NULL,
+    closed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT tender_auctions_window CHECK (starts_at < ends_at)
+);
+
+CREATE TABLE auction_prices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tender_auctions (tender_id) ON DELETE CASCADE,
+    bid_id UUID NOT NULL REFERENCES bids (id) ON DELETE CASCADE,
+    price NUMERIC(18, 2) NOT NULL,
+    placed_by VARCHAR(50),
+    placed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_auction_prices_tender_price ON auction_prices (tender_id, price, placed_at);
+
+-- +goose Down
+DROP TABLE auction_prices;
+DROP TABLE tender_auctions;
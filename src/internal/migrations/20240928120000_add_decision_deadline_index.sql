@@ -0,0 +1,5 @@
+-- +goose Up
+CREATE INDEX idx_tenders_decision_deadline ON tenders (decision_deadline) WHERE status = 'Published';
+
+-- +goose Down
+DROP INDEX idx_tenders_decision_deadline;
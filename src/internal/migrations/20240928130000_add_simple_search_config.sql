@@ -0,0 +1,29 @@
+-- +goose Up
+-- The 'simple' configuration keeps words unstemmed, so searches with a configuration
+-- the vector was not built with, or with words no stemmer knows, still match.
+DROP INDEX idx_tenders_search_vector;
+ALTER TABLE tenders DROP COLUMN search_vector;
+
+ALTER TABLE tenders ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', COALESCE(name, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(name, '')), 'A') ||
+    setweight(to_tsvector('simple', COALESCE(name, '')), 'A') ||
+    setweight(to_tsvector('russian', COALESCE(description, '')), 'B') ||
+    setweight(to_tsvector('english', COALESCE(description, '')), 'B') ||
+    setweight(to_tsvector('simple', COALESCE(description, '')), 'B')
+) STORED;
+
+CREATE INDEX idx_tenders_search_vector ON tenders USING GIN (search_vector);
+
+-- +goose Down
+DROP INDEX idx_tenders_search_vector;
+ALTER TABLE tenders DROP COLUMN search_vector;
+
+ALTER TABLE tenders ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', COALESCE(name, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(name, '')), 'A') ||
+    setweight(to_tsvector('russian', COALESCE(description, '')), 'B') ||
+    setweight(to_tsvector('english', COALESCE(description, '')), 'B')
+) STORED;
+
+CREATE INDEX idx_tenders_search_vector ON tenders USING GIN (search_vector);
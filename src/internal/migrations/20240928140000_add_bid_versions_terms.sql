@@ -0,0 +1,16 @@
+-- +goose Up
+-- The versions recorded so far keep NULL terms: what the bid offered back then was
+-- not recorded, and the current terms may differ from it. Rollbacks to such versions
+-- leave the terms of the bid as they are.
+ALTER TABLE bids_versions
+    ADD COLUMN price NUMERIC(18, 2),
+    ADD COLUMN currency CHAR(3),
+    ADD COLUMN items JSONB,
+    ADD COLUMN lot_ids UUID[];
+
+-- +goose Down
+ALTER TABLE bids_versions
+    DROP COLUMN lot_ids,
+    DROP COLUMN items,
+    DROP COLUMN currency,
+    DROP COLUMN price;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- A resubmitted bid goes through the quorum afresh. Earlier decisions are kept for
+-- the record and marked invalidated, so only current ones need to be unique.
+ALTER TABLE bid_decisions ADD COLUMN invalidated_at TIMESTAMPTZ;
+
+ALTER TABLE bid_decisions DROP CONSTRAINT uq_bid_decisions_bid_user;
+CREATE UNIQUE INDEX uq_bid_decisions_bid_user_current ON bid_decisions (bid_id, user_id) WHERE invalidated_at IS NULL;
+
+-- +goose Down
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM bid_decisions WHERE invalidated_at IS NOT NULL) THEN
+        RAISE EXCEPTION 'bid_decisions has invalidated decisions; remove them before migrating down';
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+DROP INDEX uq_bid_decisions_bid_user_current;
+ALTER TABLE bid_decisions ADD CONSTRAINT uq_bid_decisions_bid_user UNIQUE (bid_id, user_id);
+
+ALTER TABLE bid_decisions DROP COLUMN invalidated_at;
@@ -0,0 +1,263 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/domain/models"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+// auctionQuery reads the state of an auction. The best price only counts bids that
+// are still in the running, so a withdrawn bid gives up its place.
+const auctionQuery = `SELECT a.tender_id, a.status, a.starts_at, a.ends_at, a.min_decrement::text,
+		a.extension_window_minutes, a.extension_minutes, COALESCE(t.currency, ''), a.winning_bid_id, a.closed_at,
+		best.price::text, best.bid_id,
+		(SELECT COUNT(*) FROM auction_prices p WHERE p.tender_id = a.tender_id),
+		(SELECT COUNT(DISTINCT p.bid_id) FROM auction_prices p WHERE p.tender_id = a.tender_id)
+	FROM tender_auctions a
+	JOIN tenders t ON t.id = a.tender_id
+	LEFT JOIN LATERAL (
+		SELECT p.price, p.bid_id
+		FROM auction_prices p
+		JOIN bids b ON b.id = p.bid_id
+		WHERE p.tender_id = a.tender_id AND b.status = ANY($2)
+		ORDER BY p.price, p.placed_at
+		LIMIT 1
+	) best ON TRUE
+	WHERE a.tender_id = $1`
+
+func scanAuction(row pgx.Row) (dto.TenderAuctionDTO, error) {
+	var auction dto.TenderAuctionDTO
+	err := row.Scan(&auction.TenderID, &auction.Status, &auction.StartsAt, &auction.EndsAt, &auction.MinDecrement,
+		&auction.ExtensionWindowMinutes, &auction.ExtensionMinutes, &auction.Currency, &auction.WinningBidID, &auction.ClosedAt,
+		&auction.BestPrice, &auction.LeadingBidID, &auction.PriceCount, &auction.ParticipantCount)
+	return auction, err
+}
+
+func (s *Storage) GetTenderAuction(ctx context.Context, tenderID uuid.UUID) (dto.TenderAuctionDTO, error) {
+	const op = "storage.postgres.GetTenderAuction"
+
+	auction, err := s.getAuction(ctx, s.conn(ctx), tenderID)
+	if err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return auction, nil
+}
+
+// LockTenderAuction reads the auction and locks it until the end of the surrounding
+// transaction, so prices are checked against the best price one at a time.
+func (s *Storage) LockTenderAuction(ctx context.Context, tenderID uuid.UUID) (dto.TenderAuctionDTO, error) {
+	const op = "storage.postgres.LockTenderAuction"
+
+	var id uuid.UUID
+	err := s.conn(ctx).QueryRow(ctx, `SELECT tender_id FROM tender_auctions WHERE tender_id = $1 FOR UPDATE`, tenderID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, repository.ErrAuctionNotFound)
+		}
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	auction, err := s.getAuction(ctx, s.conn(ctx), tenderID)
+	if err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return auction, nil
+}
+
+func (s *Storage) getAuction(ctx context.Context, q querier, tenderID uuid.UUID) (dto.TenderAuctionDTO, error) {
+	auction, err := scanAuction(q.QueryRow(ctx, auctionQuery, tenderID,
+		[]string{models.BidStatusPublished.String(), models.BidStatusApproved.String()}))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.TenderAuctionDTO{}, repository.ErrAuctionNotFound
+		}
+		return dto.TenderAuctionDTO{}, err
+	}
+
+	return auction, nil
+}
+
+// SaveTenderAuction creates the auction of a tender or replaces its settings.
+func (s *Storage) SaveTenderAuction(ctx context.Context, tenderID uuid.UUID, auction dto.AuctionDTO) (dto.TenderAuctionDTO, error) {
+	const op = "storage.postgres.SaveTenderAuction"
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `INSERT INTO tender_auctions (tender_id, starts_at, ends_at, min_decrement, extension_window_minutes, extension_minutes)
+		VALUES ($1, $2, $3, $4::text::numeric, $5, $6)
+		ON CONFLICT (tender_id) DO UPDATE SET starts_at = EXCLUDED.starts_at, ends_at = EXCLUDED.ends_at,
+			min_decrement = EXCLUDED.min_decrement, extension_window_minutes = EXCLUDED.extension_window_minutes,
+			extension_minutes = EXCLUDED.extension_minutes, updated_at = NOW()`,
+		tenderID, auction.StartsAt, auction.EndsAt, auction.MinDecrement, auction.ExtensionWindowMinutes, auction.ExtensionMinutes)
+	if err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	saved, err := s.getAuction(ctx, tx, tenderID)
+	if err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return saved, nil
+}
+
+// PlaceAuctionPrice records a price for the bid, makes it the bid's price as a new
+// version of the bid and, when endsAt is set, extends the auction to it.
+func (s *Storage) PlaceAuctionPrice(ctx context.Context, tenderID, bidID uuid.UUID, price, username string, endsAt *time.Time) (dto.TenderAuctionDTO, error) {
+	const op = "storage.postgres.PlaceAuctionPrice"
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err = lockBid(ctx, tx, bidID); err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, `INSERT INTO auction_prices (tender_id, bid_id, price, placed_by) VALUES ($1, $2, $3::text::numeric, $4)`,
+		tenderID, bidID, price, username)
+	if err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = snapshotBid(ctx, tx, bidID); err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE bids SET price = $1::text::numeric,
+			currency = COALESCE((SELECT currency FROM tenders WHERE id = $2), currency),
+			version = version + 1, updated_by = $4, updated_at = NOW()
+		WHERE id = $3`, price, tenderID, bidID, username)
+	if err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if endsAt != nil {
+		_, err = tx.Exec(ctx, `UPDATE tender_auctions SET ends_at = $1, updated_at = NOW() WHERE tender_id = $2`, *endsAt, tenderID)
+		if err != nil {
+			return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	auction, err := s.getAuction(ctx, tx, tenderID)
+	if err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return auction, nil
+}
+
+// CloseTenderAuction stops the auction and records its winner, if any price was placed.
+func (s *Storage) CloseTenderAuction(ctx context.Context, tenderID uuid.UUID, winningBidID *uuid.UUID) (dto.TenderAuctionDTO, error) {
+	const op = "storage.postgres.CloseTenderAuction"
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err = closeAuction(ctx, tx, tenderID, winningBidID); err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	auction, err := s.getAuction(ctx, tx, tenderID)
+	if err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return auction, nil
+}
+
+// CloseEndedAuctions closes up to limit open auctions whose end has passed, recording
+// the bid with the best price as the winner. Auctions of tenders that are not published
+// yet are left alone. Auctions locked by a concurrent transaction, such as one placing a
+// price that may extend the auction, are skipped and picked up by the next run.
+func (s *Storage) CloseEndedAuctions(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	const op = "storage.postgres.CloseEndedAuctions"
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `SELECT a.tender_id FROM tender_auctions a
+		JOIN tenders t ON t.id = a.tender_id
+		WHERE a.status = $1 AND a.ends_at <= NOW() AND t.status <> $2
+		ORDER BY a.ends_at
+		LIMIT $3
+		FOR UPDATE OF a SKIP LOCKED`, models.AuctionStatusOpen, models.TenderStatusCreated, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err = rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, id := range ids {
+		auction, err := s.getAuction(ctx, tx, id)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if err = closeAuction(ctx, tx, id, auction.LeadingBidID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ids, nil
+}
+
+func closeAuction(ctx context.Context, q querier, tenderID uuid.UUID, winningBidID *uuid.UUID) error {
+	tag, err := q.Exec(ctx, `UPDATE tender_auctions SET status = $1, winning_bid_id = $2, closed_at = NOW(), updated_at = NOW()
+		WHERE tender_id = $3 AND status = $4`, models.AuctionStatusClosed, winningBidID, tenderID, models.AuctionStatusOpen)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return repository.ErrAuctionNotOpen
+	}
+	return nil
+}
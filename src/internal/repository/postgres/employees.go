@@ -0,0 +1,201 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"git.codenrock.com/avito/internal/authz"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/domain/models"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const employeeColumns = `id, username, COALESCE(first_name, ''), COALESCE(last_name, ''), is_admin, created_at, updated_at`
+
+func scanEmployee(row pgx.Row) (models.User, error) {
+	var user models.User
+	err := row.Scan(&user.ID, &user.Username, &user.FirstName, &user.LastName, &user.IsAdmin, &user.CreatedAt, &user.UpdatedAt)
+	return user, err
+}
+
+// GetEmployeeCredentials returns the employee and its bcrypt password hash. The hash
+// is empty when no password has been set for the employee.
+func (s *Storage) GetEmployeeCredentials(ctx context.Context, username string) (models.User, string, error) {
+	const op = "storage.postgres.GetEmployeeCredentials"
+
+	var (
+		user         models.User
+		passwordHash string
+	)
+	err := s.conn(ctx).QueryRow(ctx, `SELECT id, username, COALESCE(first_name, ''), COALESCE(last_name, ''), is_admin, created_at, updated_at, COALESCE(password_hash, '')
+		FROM employee WHERE username = $1`, username).Scan(
+		&user.ID,
+		&user.Username,
+		&user.FirstName,
+		&user.LastName,
+		&user.IsAdmin,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&passwordHash,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.User{}, "", fmt.Errorf("%s: %w", op, repository.ErrUserNotFound)
+		}
+		return models.User{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, passwordHash, nil
+}
+
+// GetSubject loads the employee together with the organizations it is responsible for.
+func (s *Storage) GetSubject(ctx context.Context, username string) (authz.Subject, error) {
+	const op = "storage.postgres.GetSubject"
+
+	var subject authz.Subject
+	err := s.conn(ctx).QueryRow(ctx, `SELECT e.id, e.username, e.is_admin,
+			COALESCE(array_agg(r.organization_id) FILTER (WHERE r.organization_id IS NOT NULL), '{}')
+		FROM employee e
+		LEFT JOIN organization_responsible r ON r.user_id = e.id
+		WHERE e.username = $1
+		GROUP BY e.id`, username).Scan(
+		&subject.UserID,
+		&subject.Username,
+		&subject.Admin,
+		&subject.Organizations,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return authz.Subject{}, fmt.Errorf("%s: %w", op, repository.ErrUserNotFound)
+		}
+		return authz.Subject{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return subject, nil
+}
+
+// CreateEmployee inserts a new employee. passwordHash may be empty for employees that
+// only authenticate in compat mode.
+func (s *Storage) CreateEmployee(ctx context.Context, employee dto.CreateEmployeeDTO, passwordHash string) (models.User, error) {
+	const op = "storage.postgres.CreateEmployee"
+
+	user, err := scanEmployee(s.conn(ctx).QueryRow(ctx, `INSERT INTO employee (username, first_name, last_name, password_hash, is_admin)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+		RETURNING `+employeeColumns,
+		employee.Username, employee.FirstName, employee.LastName, passwordHash, employee.IsAdmin))
+	if err != nil {
+		if isPgError(err, uniqueViolation) {
+			return models.User{}, fmt.Errorf("%s: %w", op, repository.ErrUserAlreadyExists)
+		}
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, nil
+}
+
+func (s *Storage) GetEmployees(ctx context.Context, limit, offset int) ([]models.User, error) {
+	const op = "storage.postgres.GetEmployees"
+
+	rows, err := s.conn(ctx).Query(ctx, `SELECT `+employeeColumns+`
+		FROM employee
+		ORDER BY username
+		LIMIT $1 OFFSET $2`, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	users, err := scanEmployees(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, nil
+}
+
+func (s *Storage) GetEmployee(ctx context.Context, id uuid.UUID) (models.User, error) {
+	const op = "storage.postgres.GetEmployee"
+
+	user, err := scanEmployee(s.conn(ctx).QueryRow(ctx, `SELECT `+employeeColumns+` FROM employee WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.User{}, fmt.Errorf("%s: %w", op, repository.ErrUserNotFound)
+		}
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, nil
+}
+
+func (s *Storage) GetEmployeeByUsername(ctx context.Context, username string) (models.User, error) {
+	const op = "storage.postgres.GetEmployeeByUsername"
+
+	user, err := scanEmployee(s.conn(ctx).QueryRow(ctx, `SELECT `+employeeColumns+` FROM employee WHERE username = $1`, username))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.User{}, fmt.Errorf("%s: %w", op, repository.ErrUserNotFound)
+		}
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, nil
+}
+
+// UpdateEmployee changes the fields set in data. An empty passwordHash keeps the
+// current password.
+func (s *Storage) UpdateEmployee(ctx context.Context, id uuid.UUID, data dto.UpdateEmployeeDTO, passwordHash string) (models.User, error) {
+	const op = "storage.postgres.UpdateEmployee"
+
+	user, err := scanEmployee(s.conn(ctx).QueryRow(ctx, `UPDATE employee
+		SET first_name = COALESCE($2, first_name),
+			last_name = COALESCE($3, last_name),
+			password_hash = COALESCE(NULLIF($4, ''), password_hash),
+			is_admin = COALESCE($5, is_admin),
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING `+employeeColumns,
+		id, data.FirstName, data.LastName, passwordHash, data.IsAdmin))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.User{}, fmt.Errorf("%s: %w", op, repository.ErrUserNotFound)
+		}
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, nil
+}
+
+// DeleteEmployee removes the employee together with its responsibilities. Employees
+// still referenced by bids or decisions cannot be deleted.
+func (s *Storage) DeleteEmployee(ctx context.Context, id uuid.UUID) error {
+	const op = "storage.postgres.DeleteEmployee"
+
+	tag, err := s.conn(ctx).Exec(ctx, `DELETE FROM employee WHERE id = $1`, id)
+	if err != nil {
+		if isPgError(err, foreignKeyViolation) {
+			return fmt.Errorf("%s: %w", op, repository.ErrEntityInUse)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, repository.ErrUserNotFound)
+	}
+
+	return nil
+}
+
+func scanEmployees(rows pgx.Rows) ([]models.User, error) {
+	defer rows.Close()
+
+	users := make([]models.User, 0)
+	for rows.Next() {
+		user, err := scanEmployee(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
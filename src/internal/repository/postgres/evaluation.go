@@ -0,0 +1,274 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/domain/models"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+)
+
+func (s *Storage) GetTenderCriteria(ctx context.Context, tenderID uuid.UUID) ([]dto.TenderCriterionDTO, error) {
+	const op = "storage.postgres.GetTenderCriteria"
+
+	criteria, err := tenderCriteria(ctx, s.conn(ctx), tenderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return criteria, nil
+}
+
+// ReplaceTenderCriteria swaps the whole set of criteria of a tender. Scores refer to
+// criteria, so the set is frozen once any bid of the tender has been scored.
+func (s *Storage) ReplaceTenderCriteria(ctx context.Context, tenderID uuid.UUID, criteria []dto.CriterionDTO) ([]dto.TenderCriterionDTO, error) {
+	const op = "storage.postgres.ReplaceTenderCriteria"
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var scored bool
+	err = tx.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM bid_scores s JOIN tender_criteria c ON c.id = s.criterion_id WHERE c.tender_id = $1)`,
+		tenderID).Scan(&scored)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if scored {
+		return nil, fmt.Errorf("%s: %w", op, repository.ErrCriteriaLocked)
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM tender_criteria WHERE tender_id = $1`, tenderID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i, criterion := range criteria {
+		_, err = tx.Exec(ctx, `INSERT INTO tender_criteria (tender_id, position, name, weight) VALUES ($1, $2, $3, $4::text::numeric)`,
+			tenderID, i+1, criterion.Name, criterion.Weight)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	replaced, err := tenderCriteria(ctx, tx, tenderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return replaced, nil
+}
+
+// SaveBidScores records the scores one responsible gives a bid, replacing the ones
+// they gave before on the same criteria.
+func (s *Storage) SaveBidScores(ctx context.Context, bidID, tenderID, userID uuid.UUID, scores []dto.ScoreDTO) ([]dto.BidScoreDTO, error) {
+	const op = "storage.postgres.SaveBidScores"
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	ids := make([]uuid.UUID, 0, len(scores))
+	for _, score := range scores {
+		ids = append(ids, score.CriterionID)
+	}
+
+	var known int
+	err = tx.QueryRow(ctx, `SELECT COUNT(*) FROM tender_criteria WHERE tender_id = $1 AND id = ANY($2)`, tenderID, ids).Scan(&known)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if known != len(ids) {
+		return nil, fmt.Errorf("%s: %w", op, repository.ErrCriterionNotFound)
+	}
+
+	for _, score := range scores {
+		_, err = tx.Exec(ctx, `INSERT INTO bid_scores (bid_id, criterion_id, user_id, score, comment, created_at, updated_at)
+			VALUES ($1, $2, $3, $4::text::numeric, NULLIF($5, ''), NOW(), NOW())
+			ON CONFLICT (bid_id, criterion_id, user_id) DO UPDATE SET score = EXCLUDED.score, comment = EXCLUDED.comment, updated_at = NOW()`,
+			bidID, score.CriterionID, userID, score.Score, score.Comment)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	saved, err := bidScores(ctx, tx, bidID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return saved, nil
+}
+
+func (s *Storage) GetBidScores(ctx context.Context, bidID uuid.UUID) ([]dto.BidScoreDTO, error) {
+	const op = "storage.postgres.GetBidScores"
+
+	scores, err := bidScores(ctx, s.conn(ctx), bidID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return scores, nil
+}
+
+// GetTenderScoreboard returns the published and approved bids of a tender with the
+// average score of each criterion, in criterion order. Weighting and ranking are left
+// to the caller.
+func (s *Storage) GetTenderScoreboard(ctx context.Context, tenderID uuid.UUID) ([]dto.BidRankingDTO, error) {
+	const op = "storage.postgres.GetTenderScoreboard"
+
+	board, err := scoreboard(ctx, s.conn(ctx), tenderID, "b.status = ANY($2)",
+		[]string{models.BidStatusPublished.String(), models.BidStatusApproved.String()})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return board, nil
+}
+
+// GetBidsScoreboard is GetTenderScoreboard for the given bids of the tender, whatever
+// their status.
+func (s *Storage) GetBidsScoreboard(ctx context.Context, tenderID uuid.UUID, bidIDs []uuid.UUID) ([]dto.BidRankingDTO, error) {
+	const op = "storage.postgres.GetBidsScoreboard"
+
+	board, err := scoreboard(ctx, s.conn(ctx), tenderID, "b.id = ANY($2)", bidIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return board, nil
+}
+
+func scoreboard(ctx context.Context, q querier, tenderID uuid.UUID, condition string, arg any) ([]dto.BidRankingDTO, error) {
+	rows, err := q.Query(ctx, `SELECT b.id, b.name, b.status, c.id, c.name, c.weight::text,
+			ROUND(AVG(s.score), 2)::text, COUNT(s.user_id)
+		FROM bids b
+		JOIN tender_criteria c ON c.tender_id = b.tender_id
+		LEFT JOIN bid_scores s ON s.bid_id = b.id AND s.criterion_id = c.id
+		WHERE b.tender_id = $1 AND `+condition+`
+		GROUP BY b.id, c.id
+		ORDER BY b.created_at, b.id, c.position`, tenderID, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	board := []dto.BidRankingDTO{}
+	for rows.Next() {
+		var bidID uuid.UUID
+		var bidName, status string
+		var criterion dto.CriterionScoreDTO
+		var average *string
+		err = rows.Scan(&bidID, &bidName, &status, &criterion.CriterionID, &criterion.Name, &criterion.Weight, &average, &criterion.Scorers)
+		if err != nil {
+			return nil, err
+		}
+		if average != nil {
+			criterion.Average = *average
+		}
+
+		if len(board) == 0 || board[len(board)-1].BidID != bidID {
+			board = append(board, dto.BidRankingDTO{BidID: bidID, BidName: bidName, Status: status})
+		}
+		last := &board[len(board)-1]
+		last.Criteria = append(last.Criteria, criterion)
+	}
+
+	return board, rows.Err()
+}
+
+// GetComparedBids returns the given bids of the tender with their organization and
+// number of reviews. Any id that is not a bid of the tender fails the whole call.
+func (s *Storage) GetComparedBids(ctx context.Context, tenderID uuid.UUID, bidIDs []uuid.UUID) ([]dto.ComparedBidDTO, error) {
+	const op = "storage.postgres.GetComparedBids"
+
+	rows, err := s.conn(ctx).Query(ctx, `SELECT b.id, b.name, b.status, b.version, COALESCE(b.author_type, ''), b.author_id,
+			b.organization_id, COALESCE(o.name, ''), b.price::text, COALESCE(b.currency, ''),
+			(SELECT COUNT(*) FROM bid_reviews r WHERE r.bid_id = b.id)
+		FROM bids b
+		LEFT JOIN organization o ON o.id = b.organization_id
+		WHERE b.tender_id = $1 AND b.id = ANY($2)
+		ORDER BY array_position($2, b.id)`, tenderID, bidIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	bids := []dto.ComparedBidDTO{}
+	for rows.Next() {
+		var bid dto.ComparedBidDTO
+		err = rows.Scan(&bid.BidID, &bid.Name, &bid.Status, &bid.Version, &bid.AuthorType, &bid.AuthorID,
+			&bid.OrganizationID, &bid.OrganizationName, &bid.Price, &bid.Currency, &bid.ReviewCount)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		bids = append(bids, bid)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(bids) != len(bidIDs) {
+		return nil, fmt.Errorf("%s: %w", op, repository.ErrBidNotFound)
+	}
+
+	return bids, nil
+}
+
+func tenderCriteria(ctx context.Context, q querier, tenderID uuid.UUID) ([]dto.TenderCriterionDTO, error) {
+	rows, err := q.Query(ctx, `SELECT id, tender_id, position, name, weight::text, created_at
+		FROM tender_criteria WHERE tender_id = $1 ORDER BY position`, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	criteria := []dto.TenderCriterionDTO{}
+	for rows.Next() {
+		var criterion dto.TenderCriterionDTO
+		err = rows.Scan(&criterion.ID, &criterion.TenderID, &criterion.Position, &criterion.Name, &criterion.Weight, &criterion.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		criteria = append(criteria, criterion)
+	}
+
+	return criteria, rows.Err()
+}
+
+func bidScores(ctx context.Context, q querier, bidID uuid.UUID) ([]dto.BidScoreDTO, error) {
+	rows, err := q.Query(ctx, `SELECT s.bid_id, s.criterion_id, c.name, e.username, s.score::text, COALESCE(s.comment, ''), s.updated_at
+		FROM bid_scores s
+		JOIN tender_criteria c ON c.id = s.criterion_id
+		JOIN employee e ON e.id = s.user_id
+		WHERE s.bid_id = $1
+		ORDER BY c.position, e.username`, bidID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scores := []dto.BidScoreDTO{}
+	for rows.Next() {
+		var score dto.BidScoreDTO
+		err = rows.Scan(&score.BidID, &score.CriterionID, &score.CriterionName, &score.Username, &score.Score, &score.Comment, &score.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		scores = append(scores, score)
+	}
+
+	return scores, rows.Err()
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const invitationColumns = `i.tender_id, i.organization_id, o.name, COALESCE(i.invited_by, ''), i.created_at`
+
+func scanInvitation(row pgx.Row) (dto.TenderInvitationDTO, error) {
+	var invitation dto.TenderInvitationDTO
+	err := row.Scan(&invitation.TenderID, &invitation.OrganizationID, &invitation.OrganizationName, &invitation.InvitedBy,
+		&invitation.CreatedAt)
+	return invitation, err
+}
+
+func (s *Storage) GetTenderInvitations(ctx context.Context, tenderID uuid.UUID) ([]dto.TenderInvitationDTO, error) {
+	const op = "storage.postgres.GetTenderInvitations"
+
+	rows, err := s.conn(ctx).Query(ctx, `SELECT `+invitationColumns+`
+		FROM tender_invitations i JOIN organization o ON o.id = i.organization_id
+		WHERE i.tender_id = $1
+		ORDER BY i.created_at, i.organization_id`, tenderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	invitations := []dto.TenderInvitationDTO{}
+	for rows.Next() {
+		invitation, err := scanInvitation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		invitations = append(invitations, invitation)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return invitations, nil
+}
+
+// CreateTenderInvitation adds the organization to the invitation list of the tender.
+// Inviting an organization again keeps the original invitation.
+func (s *Storage) CreateTenderInvitation(ctx context.Context, tenderID, organizationID uuid.UUID, invitedBy string) (dto.TenderInvitationDTO, error) {
+	const op = "storage.postgres.CreateTenderInvitation"
+
+	_, err := s.conn(ctx).Exec(ctx, `INSERT INTO tender_invitations (tender_id, organization_id, invited_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (tender_id, organization_id) DO NOTHING`, tenderID, organizationID, invitedBy)
+	if err != nil {
+		if isPgError(err, foreignKeyViolation) {
+			return dto.TenderInvitationDTO{}, fmt.Errorf("%s: %w", op, repository.ErrOrganizationNotFound)
+		}
+		return dto.TenderInvitationDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	invitation, err := scanInvitation(s.conn(ctx).QueryRow(ctx, `SELECT `+invitationColumns+`
+		FROM tender_invitations i JOIN organization o ON o.id = i.organization_id
+		WHERE i.tender_id = $1 AND i.organization_id = $2`, tenderID, organizationID))
+	if err != nil {
+		return dto.TenderInvitationDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return invitation, nil
+}
+
+func (s *Storage) DeleteTenderInvitation(ctx context.Context, tenderID, organizationID uuid.UUID) error {
+	const op = "storage.postgres.DeleteTenderInvitation"
+
+	tag, err := s.conn(ctx).Exec(ctx, `DELETE FROM tender_invitations WHERE tender_id = $1 AND organization_id = $2`,
+		tenderID, organizationID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, repository.ErrInvitationNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,240 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+	"strconv"
+	"strings"
+)
+
+// listSpec is the whitelist of filter and sort fields a listing supports. Filters map
+// a field to an SQL condition with a single %s placeholder for its argument; sorts
+// map a field to a column. Only these fragments ever reach the SQL text. createdAt
+// and id are the keyset columns used by cursor pagination.
+type listSpec struct {
+	filters     map[string]string
+	sorts       map[string]string
+	defaultSort []dto.SortField
+	createdAt   string
+	id          string
+}
+
+var tenderListSpec = listSpec{
+	filters: map[string]string{
+		"status":          "status = ANY(%s)",
+		"organization_id": "organization_id = %s",
+		"creator":         "creator_username = %s",
+		"service_type":    "service_type = ANY(%s)",
+		"created_from":    "created_at >= %s",
+		"created_to":      "created_at < %s",
+		"updated_from":    "updated_at >= %s",
+		"updated_to":      "updated_at < %s",
+	},
+	sorts: map[string]string{
+		"name":                "name",
+		"status":              "status",
+		"service_type":        "service_type",
+		"version":             "version",
+		"created_at":          "created_at",
+		"updated_at":          "updated_at",
+		"submission_deadline": "submission_deadline",
+	},
+	defaultSort: []dto.SortField{{Field: "created_at", Desc: true}},
+	createdAt:   "created_at",
+	id:          "id",
+}
+
+var bidListSpec = listSpec{
+	filters: map[string]string{
+		"status":          "status = ANY(%s)",
+		"organization_id": "organization_id = %s",
+		"creator":         "author_type = 'User' AND author_id = (SELECT id FROM employee WHERE username = %s)",
+		"created_from":    "created_at >= %s",
+		"created_to":      "created_at < %s",
+		"updated_from":    "updated_at >= %s",
+		"updated_to":      "updated_at < %s",
+	},
+	sorts: map[string]string{
+		"name":       "name",
+		"status":     "status",
+		"version":    "version",
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	defaultSort: []dto.SortField{{Field: "created_at"}},
+	createdAt:   "created_at",
+	id:          "id",
+}
+
+var reviewListSpec = listSpec{
+	filters: map[string]string{
+		"created_from": "r.created_at >= %s",
+		"created_to":   "r.created_at < %s",
+	},
+	sorts: map[string]string{
+		"created_at": "r.created_at",
+	},
+	defaultSort: []dto.SortField{{Field: "created_at", Desc: true}},
+	createdAt:   "r.created_at",
+	id:          "r.id",
+}
+
+var questionListSpec = listSpec{
+	filters: map[string]string{
+		"created_from": "q.created_at >= %s",
+		"created_to":   "q.created_at < %s",
+	},
+	sorts: map[string]string{
+		"created_at": "q.created_at",
+	},
+	defaultSort: []dto.SortField{{Field: "created_at"}},
+	createdAt:   "q.created_at",
+	id:          "q.id",
+}
+
+// listBuilder collects the conditions and positional arguments of a listing query.
+type listBuilder struct {
+	conditions []string
+	args       []any
+}
+
+func (b *listBuilder) arg(value any) string {
+	b.args = append(b.args, value)
+	return "$" + strconv.Itoa(len(b.args))
+}
+
+func (b *listBuilder) where(condition string, value any) {
+	b.conditions = append(b.conditions, fmt.Sprintf(condition, b.arg(value)))
+}
+
+// page returns the LIMIT clause of a listing. A listing continued from a cursor is
+// already positioned by seek, so the offset only applies to the first page.
+func (b *listBuilder) page(q dto.ListQueryDTO) string {
+	clause := "LIMIT " + b.arg(q.Limit)
+	if q.After == nil {
+		clause += " OFFSET " + b.arg(q.Offset)
+	}
+	return clause
+}
+
+func (b *listBuilder) whereClause() string {
+	if len(b.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(b.conditions, " AND ")
+}
+
+// listOrder is the ORDER BY expression of a listing. A listing ordered by created_at
+// alone is ordered by (created_at, id) in one direction and can be continued from a
+// cursor.
+type listOrder struct {
+	sql    string
+	keyset bool
+	desc   bool
+}
+
+// apply adds the filters of q to b and returns the order of the listing. Rows are
+// always ordered by id last, so pages are stable. Full-text search is handled by the
+// listings that support it, which clear q.Query before calling apply. The cursor in
+// q.After is not applied here, see seek.
+func (spec listSpec) apply(b *listBuilder, q dto.ListQueryDTO) (listOrder, error) {
+	if q.Query != "" {
+		return listOrder{}, fmt.Errorf("%w: q", repository.ErrInvalidListQuery)
+	}
+
+	filters := []struct {
+		field string
+		set   bool
+		value any
+	}{
+		{"status", len(q.Statuses) > 0, q.Statuses},
+		{"organization_id", q.OrganizationID != uuid.Nil, q.OrganizationID},
+		{"creator", q.Creator != "", q.Creator},
+		{"service_type", len(q.ServiceTypes) > 0, q.ServiceTypes},
+		{"created_from", q.CreatedFrom != nil, q.CreatedFrom},
+		{"created_to", q.CreatedTo != nil, q.CreatedTo},
+		{"updated_from", q.UpdatedFrom != nil, q.UpdatedFrom},
+		{"updated_to", q.UpdatedTo != nil, q.UpdatedTo},
+	}
+	for _, f := range filters {
+		if !f.set {
+			continue
+		}
+		condition, ok := spec.filters[f.field]
+		if !ok {
+			return listOrder{}, fmt.Errorf("%w: %s", repository.ErrInvalidListQuery, f.field)
+		}
+		b.where(condition, f.value)
+	}
+
+	sort := q.Sort
+	if len(sort) == 0 {
+		sort = spec.defaultSort
+	}
+
+	if len(sort) == 1 && sort[0].Field == "created_at" {
+		direction := " ASC"
+		if sort[0].Desc {
+			direction = " DESC"
+		}
+		return listOrder{
+			sql:    spec.createdAt + direction + ", " + spec.id + direction,
+			keyset: true,
+			desc:   sort[0].Desc,
+		}, nil
+	}
+
+	order := make([]string, 0, len(sort)+1)
+	for _, field := range sort {
+		column, ok := spec.sorts[field.Field]
+		if !ok {
+			return listOrder{}, fmt.Errorf("%w: %s", repository.ErrInvalidListQuery, field.Field)
+		}
+		if field.Desc {
+			column += " DESC"
+		}
+		order = append(order, column)
+	}
+	order = append(order, spec.id)
+
+	return listOrder{sql: strings.Join(order, ", ")}, nil
+}
+
+// seek restricts b to the rows after the cursor. Cursors only make sense for listings
+// ordered by created_at, so any other order rejects them.
+func (spec listSpec) seek(b *listBuilder, order listOrder, after *dto.Cursor) error {
+	if after == nil {
+		return nil
+	}
+	if !order.keyset {
+		return fmt.Errorf("%w: after", repository.ErrInvalidListQuery)
+	}
+
+	comparison := ">"
+	if order.desc {
+		comparison = "<"
+	}
+	b.conditions = append(b.conditions, fmt.Sprintf("(%s, %s) %s (%s, %s)",
+		spec.createdAt, spec.id, comparison, b.arg(after.CreatedAt), b.arg(after.ID)))
+
+	return nil
+}
+
+// nextCursor returns the cursor of the page following items, or an empty string when
+// the page is the last one or the order does not support cursors.
+func nextCursor(order listOrder, limit, count int, last func() dto.Cursor) string {
+	if !order.keyset || limit <= 0 || count < limit {
+		return ""
+	}
+	return last().Encode()
+}
+
+// countRows counts the rows of from matching the conditions collected in b so far.
+func (s *Storage) countRows(ctx context.Context, from string, b *listBuilder) (int, error) {
+	var total int
+	err := s.conn(ctx).QueryRow(ctx, `SELECT COUNT(*) FROM `+from+b.whereClause(), b.args...).Scan(&total)
+	return total, err
+}
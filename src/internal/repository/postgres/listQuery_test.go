@@ -0,0 +1,204 @@
+package postgres
+
+import (
+	"errors"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestListSpecApply(t *testing.T) {
+	org := uuid.New()
+	from := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		spec      listSpec
+		q         dto.ListQueryDTO
+		wantSQL   string
+		wantKeys  bool
+		wantDesc  bool
+		wantWhere string
+		wantArgs  []any
+		wantErr   error
+	}{
+		{
+			name:     "default tender order",
+			spec:     tenderListSpec,
+			wantSQL:  "created_at DESC, id DESC",
+			wantKeys: true,
+			wantDesc: true,
+		},
+		{
+			name:     "default bid order",
+			spec:     bidListSpec,
+			wantSQL:  "created_at ASC, id ASC",
+			wantKeys: true,
+		},
+		{
+			name:     "prefixed columns",
+			spec:     reviewListSpec,
+			q:        dto.ListQueryDTO{Sort: []dto.SortField{{Field: "created_at"}}},
+			wantSQL:  "r.created_at ASC, r.id ASC",
+			wantKeys: true,
+		},
+		{
+			name: "filters in a fixed order",
+			spec: tenderListSpec,
+			q: dto.ListQueryDTO{
+				Statuses:       []string{"Published"},
+				OrganizationID: org,
+				ServiceTypes:   []string{"Delivery", "Construction"},
+				CreatedFrom:    &from,
+			},
+			wantSQL:   "created_at DESC, id DESC",
+			wantKeys:  true,
+			wantDesc:  true,
+			wantWhere: " WHERE status = ANY($1) AND organization_id = $2 AND service_type = ANY($3) AND created_at >= $4",
+			wantArgs:  []any{[]string{"Published"}, org, []string{"Delivery", "Construction"}, &from},
+		},
+		{
+			name:    "sort by other fields ends with id",
+			spec:    tenderListSpec,
+			q:       dto.ListQueryDTO{Sort: []dto.SortField{{Field: "name"}, {Field: "version", Desc: true}}},
+			wantSQL: "name, version DESC, id",
+		},
+		{
+			name:    "created_at among other fields is not a keyset",
+			spec:    bidListSpec,
+			q:       dto.ListQueryDTO{Sort: []dto.SortField{{Field: "status"}, {Field: "created_at", Desc: true}}},
+			wantSQL: "status, created_at DESC, id",
+		},
+		{name: "full-text query", spec: bidListSpec, q: dto.ListQueryDTO{Query: "pipes"}, wantErr: repository.ErrInvalidListQuery},
+		{name: "unsupported filter", spec: bidListSpec, q: dto.ListQueryDTO{ServiceTypes: []string{"Delivery"}}, wantErr: repository.ErrInvalidListQuery},
+		{name: "unsupported sort", spec: reviewListSpec, q: dto.ListQueryDTO{Sort: []dto.SortField{{Field: "name"}}}, wantErr: repository.ErrInvalidListQuery},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b listBuilder
+			order, err := tt.spec.apply(&b, tt.q)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("apply() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("apply() error = %v", err)
+			}
+
+			if order.sql != tt.wantSQL || order.keyset != tt.wantKeys || order.desc != tt.wantDesc {
+				t.Errorf("apply() order = %+v, want {sql:%s keyset:%v desc:%v}", order, tt.wantSQL, tt.wantKeys, tt.wantDesc)
+			}
+			if got := b.whereClause(); got != tt.wantWhere {
+				t.Errorf("whereClause() = %q, want %q", got, tt.wantWhere)
+			}
+			if !reflect.DeepEqual(b.args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", b.args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestListSpecSeek(t *testing.T) {
+	cursor := dto.Cursor{CreatedAt: time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC), ID: uuid.New()}
+
+	tests := []struct {
+		name      string
+		order     listOrder
+		after     *dto.Cursor
+		wantWhere string
+		wantErr   error
+	}{
+		{name: "no cursor", order: listOrder{keyset: true}},
+		{name: "no cursor on other order", order: listOrder{}},
+		{name: "ascending", order: listOrder{keyset: true}, after: &cursor,
+			wantWhere: " WHERE status = $1 AND (created_at, id) > ($2, $3)"},
+		{name: "descending", order: listOrder{keyset: true, desc: true}, after: &cursor,
+			wantWhere: " WHERE status = $1 AND (created_at, id) < ($2, $3)"},
+		{name: "cursor on other order", order: listOrder{}, after: &cursor, wantErr: repository.ErrInvalidListQuery},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b listBuilder
+			b.where("status = %s", "Published")
+
+			err := tenderListSpec.seek(&b, tt.order, tt.after)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("seek() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			want := tt.wantWhere
+			if want == "" {
+				want = " WHERE status = $1"
+			}
+			if got := b.whereClause(); got != want {
+				t.Errorf("whereClause() = %q, want %q", got, want)
+			}
+			if tt.after != nil && (b.args[1] != cursor.CreatedAt || b.args[2] != cursor.ID) {
+				t.Errorf("args = %v, want cursor values last", b.args)
+			}
+		})
+	}
+}
+
+func TestListBuilderPage(t *testing.T) {
+	cursor := dto.Cursor{CreatedAt: time.Now(), ID: uuid.New()}
+
+	tests := []struct {
+		name     string
+		q        dto.ListQueryDTO
+		want     string
+		wantArgs []any
+	}{
+		{"first page", dto.ListQueryDTO{Limit: 5, Offset: 10}, "LIMIT $2 OFFSET $3", []any{"x", 5, 10}},
+		{"after a cursor", dto.ListQueryDTO{Limit: 5, After: &cursor}, "LIMIT $2", []any{"x", 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b listBuilder
+			b.arg("x")
+			if got := b.page(tt.q); got != tt.want {
+				t.Errorf("page() = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(b.args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", b.args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestNextCursor(t *testing.T) {
+	cursor := dto.Cursor{CreatedAt: time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC), ID: uuid.New()}
+	last := func() dto.Cursor { return cursor }
+
+	tests := []struct {
+		name         string
+		order        listOrder
+		limit, count int
+		want         string
+	}{
+		{"full page", listOrder{keyset: true}, 5, 5, cursor.Encode()},
+		{"last page", listOrder{keyset: true}, 5, 3, ""},
+		{"empty page", listOrder{keyset: true}, 5, 0, ""},
+		{"no limit", listOrder{keyset: true}, 0, 0, ""},
+		{"other order", listOrder{}, 5, 5, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextCursor(tt.order, tt.limit, tt.count, last); got != tt.want {
+				t.Errorf("nextCursor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/domain/models"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const lotColumns = `id, tender_id, position, name, COALESCE(description, ''), status, awarded_bid_id, created_at, updated_at`
+
+func scanLot(row pgx.Row) (dto.TenderLotDTO, error) {
+	var lot dto.TenderLotDTO
+	err := row.Scan(&lot.ID, &lot.TenderID, &lot.Position, &lot.Name, &lot.Description, &lot.Status, &lot.AwardedBidID,
+		&lot.CreatedAt, &lot.UpdatedAt)
+	return lot, err
+}
+
+func (s *Storage) CreateTenderLots(ctx context.Context, tenderID uuid.UUID, lots []dto.LotDTO) ([]dto.TenderLotDTO, error) {
+	const op = "storage.postgres.CreateTenderLots"
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	created, err := insertLots(ctx, tx, tenderID, lots)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return created, nil
+}
+
+func (s *Storage) GetTenderLots(ctx context.Context, tenderID uuid.UUID) ([]dto.TenderLotDTO, error) {
+	const op = "storage.postgres.GetTenderLots"
+
+	rows, err := s.conn(ctx).Query(ctx, `SELECT `+lotColumns+` FROM tender_lots WHERE tender_id = $1 ORDER BY position`, tenderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	lots := []dto.TenderLotDTO{}
+	for rows.Next() {
+		lot, err := scanLot(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		lots = append(lots, lot)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return lots, nil
+}
+
+// CancelTenderLot cancels an open lot. Cancelling the last open lot of a published
+// tender closes the tender; the service refuses it for an unpublished one.
+func (s *Storage) CancelTenderLot(ctx context.Context, tenderID, lotID uuid.UUID, username string) (dto.TenderLotDTO, error) {
+	const op = "storage.postgres.CancelTenderLot"
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return dto.TenderLotDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var status string
+	err = tx.QueryRow(ctx, `SELECT status FROM tender_lots WHERE id = $1 AND tender_id = $2 FOR UPDATE`, lotID, tenderID).Scan(&status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.TenderLotDTO{}, fmt.Errorf("%s: %w", op, repository.ErrLotNotFound)
+		}
+		return dto.TenderLotDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if status != models.LotStatusOpen.String() {
+		return dto.TenderLotDTO{}, fmt.Errorf("%s: %w", op, repository.ErrLotNotOpen)
+	}
+
+	lot, err := scanLot(tx.QueryRow(ctx, `UPDATE tender_lots SET status = $1, updated_at = NOW() WHERE id = $2 RETURNING `+lotColumns,
+		models.LotStatusCancelled, lotID))
+	if err != nil {
+		return dto.TenderLotDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = closeSettledTender(ctx, tx, tenderID, username); err != nil {
+		return dto.TenderLotDTO{}, fmt.Errorf("%s: %w: %w", op, repository.ErrTenderCloseFailed, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return dto.TenderLotDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return lot, nil
+}
+
+// insertLots appends lots to the tender after its existing ones. The caller holds the
+// tender lock, so positions do not race.
+func insertLots(ctx context.Context, q querier, tenderID uuid.UUID, lots []dto.LotDTO) ([]dto.TenderLotDTO, error) {
+	created := make([]dto.TenderLotDTO, 0, len(lots))
+	for _, lot := range lots {
+		inserted, err := scanLot(q.QueryRow(ctx, `INSERT INTO tender_lots (tender_id, position, name, description)
+			SELECT $1, COALESCE(MAX(position), 0) + 1, $2, NULLIF($3, '') FROM tender_lots WHERE tender_id = $1
+			RETURNING `+lotColumns, tenderID, lot.Name, lot.Description))
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, inserted)
+	}
+	return created, nil
+}
+
+// attachBidLots links a new bid to the lots it is for. They must be open lots of the
+// tender, and a tender with lots needs at least one.
+func attachBidLots(ctx context.Context, q querier, tenderID, bidID uuid.UUID, lotIDs []uuid.UUID) error {
+	unique := make(map[uuid.UUID]struct{}, len(lotIDs))
+	ids := make([]uuid.UUID, 0, len(lotIDs))
+	for _, id := range lotIDs {
+		if _, ok := unique[id]; !ok {
+			unique[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+
+	var total, open int
+	err := q.QueryRow(ctx, `SELECT COUNT(*), COUNT(*) FILTER (WHERE id = ANY($2) AND status = $3)
+		FROM tender_lots WHERE tender_id = $1`, tenderID, ids, models.LotStatusOpen).Scan(&total, &open)
+	if err != nil {
+		return err
+	}
+	if (total == 0 && len(ids) > 0) || (total > 0 && len(ids) == 0) || open != len(ids) {
+		return repository.ErrInvalidBidLots
+	}
+
+	for _, id := range ids {
+		if _, err = q.Exec(ctx, `INSERT INTO bid_lots (bid_id, lot_id) VALUES ($1, $2)`, bidID, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkBidLotsOpen rejects decisions on a bid whose lots have all been awarded or
+// cancelled. Tenders without lots always pass.
+func checkBidLotsOpen(ctx context.Context, q querier, tenderID, bidID uuid.UUID) error {
+	var hasLots bool
+	var open int
+	err := q.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM tender_lots WHERE tender_id = $1),
+			(SELECT COUNT(*) FROM bid_lots b JOIN tender_lots l ON l.id = b.lot_id WHERE b.bid_id = $2 AND l.status = $3)`,
+		tenderID, bidID, models.LotStatusOpen).Scan(&hasLots, &open)
+	if err != nil {
+		return err
+	}
+	if hasLots && open == 0 {
+		return repository.ErrLotNotOpen
+	}
+	return nil
+}
+
+// awardBidLots awards the still open lots of an approved bid to it.
+func awardBidLots(ctx context.Context, q querier, bidID uuid.UUID) error {
+	_, err := q.Exec(ctx, `UPDATE tender_lots SET status = $1, awarded_bid_id = $2, updated_at = NOW()
+		WHERE status = $3 AND id IN (SELECT lot_id FROM bid_lots WHERE bid_id = $2)`,
+		models.LotStatusAwarded, bidID, models.LotStatusOpen)
+	return err
+}
+
+// closeSettledTender closes the tender once none of its lots is open. A tender without
+// lots is settled by its first approved bid.
+func closeSettledTender(ctx context.Context, q querier, tenderID uuid.UUID, actor string) error {
+	var open bool
+	err := q.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM tender_lots WHERE tender_id = $1 AND status = $2)`,
+		tenderID, models.LotStatusOpen).Scan(&open)
+	if err != nil || open {
+		return err
+	}
+	return closeTender(ctx, q, tenderID, actor)
+}
@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/domain/models"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const organizationColumns = `id, name, COALESCE(description, ''), COALESCE(type::text, ''), created_at, updated_at`
+
+func scanOrganization(row pgx.Row) (models.Organization, error) {
+	var org models.Organization
+	err := row.Scan(&org.ID, &org.Name, &org.Description, &org.Type, &org.CreatedAt, &org.UpdatedAt)
+	return org, err
+}
+
+func (s *Storage) CreateOrganization(ctx context.Context, org dto.OrganizationDTO) (models.Organization, error) {
+	const op = "storage.postgres.CreateOrganization"
+
+	created, err := scanOrganization(s.conn(ctx).QueryRow(ctx, `INSERT INTO organization (name, description, type)
+		VALUES ($1, $2, $3::organization_type)
+		RETURNING `+organizationColumns,
+		org.Name, org.Description, string(org.Type)))
+	if err != nil {
+		return models.Organization{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return created, nil
+}
+
+func (s *Storage) GetOrganizations(ctx context.Context, limit, offset int) ([]models.Organization, error) {
+	const op = "storage.postgres.GetOrganizations"
+
+	rows, err := s.conn(ctx).Query(ctx, `SELECT `+organizationColumns+`
+		FROM organization
+		ORDER BY name, id
+		LIMIT $1 OFFSET $2`, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	organizations := make([]models.Organization, 0)
+	for rows.Next() {
+		org, err := scanOrganization(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		organizations = append(organizations, org)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return organizations, nil
+}
+
+func (s *Storage) GetOrganization(ctx context.Context, id uuid.UUID) (models.Organization, error) {
+	const op = "storage.postgres.GetOrganization"
+
+	org, err := scanOrganization(s.conn(ctx).QueryRow(ctx, `SELECT `+organizationColumns+` FROM organization WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Organization{}, fmt.Errorf("%s: %w", op, repository.ErrOrganizationNotFound)
+		}
+		return models.Organization{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return org, nil
+}
+
+// UpdateOrganization changes the fields set in data.
+func (s *Storage) UpdateOrganization(ctx context.Context, id uuid.UUID, data dto.UpdateOrganizationDTO) (models.Organization, error) {
+	const op = "storage.postgres.UpdateOrganization"
+
+	var orgType *string
+	if data.Type != nil {
+		t := string(*data.Type)
+		orgType = &t
+	}
+
+	org, err := scanOrganization(s.conn(ctx).QueryRow(ctx, `UPDATE organization
+		SET name = COALESCE($2, name),
+			description = COALESCE($3, description),
+			type = COALESCE($4::organization_type, type),
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING `+organizationColumns,
+		id, data.Name, data.Description, orgType))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Organization{}, fmt.Errorf("%s: %w", op, repository.ErrOrganizationNotFound)
+		}
+		return models.Organization{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return org, nil
+}
+
+// DeleteOrganization removes the organization and its responsibles. Organizations
+// that still own tenders or bids cannot be deleted.
+func (s *Storage) DeleteOrganization(ctx context.Context, id uuid.UUID) error {
+	const op = "storage.postgres.DeleteOrganization"
+
+	tag, err := s.conn(ctx).Exec(ctx, `DELETE FROM organization WHERE id = $1`, id)
+	if err != nil {
+		if isPgError(err, foreignKeyViolation) {
+			return fmt.Errorf("%s: %w", op, repository.ErrEntityInUse)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, repository.ErrOrganizationNotFound)
+	}
+
+	return nil
+}
+
+// GetOrganizationResponsibles returns the employees responsible for the organization.
+func (s *Storage) GetOrganizationResponsibles(ctx context.Context, organizationID uuid.UUID) ([]models.User, error) {
+	const op = "storage.postgres.GetOrganizationResponsibles"
+
+	rows, err := s.conn(ctx).Query(ctx, `SELECT e.id, e.username, COALESCE(e.first_name, ''), COALESCE(e.last_name, ''), e.is_admin, e.created_at, e.updated_at
+		FROM organization_responsible r
+		JOIN employee e ON e.id = r.user_id
+		WHERE r.organization_id = $1
+		ORDER BY e.username`, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	users, err := scanEmployees(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, nil
+}
+
+func (s *Storage) AddOrganizationResponsible(ctx context.Context, organizationID, userID uuid.UUID) error {
+	const op = "storage.postgres.AddOrganizationResponsible"
+
+	_, err := s.conn(ctx).Exec(ctx, `INSERT INTO organization_responsible (organization_id, user_id) VALUES ($1, $2)`,
+		organizationID, userID)
+	if err != nil {
+		if isPgError(err, uniqueViolation) {
+			return fmt.Errorf("%s: %w", op, repository.ErrResponsibleAlreadyExists)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) RemoveOrganizationResponsible(ctx context.Context, organizationID, userID uuid.UUID) error {
+	const op = "storage.postgres.RemoveOrganizationResponsible"
+
+	tag, err := s.conn(ctx).Exec(ctx, `DELETE FROM organization_responsible WHERE organization_id = $1 AND user_id = $2`,
+		organizationID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, repository.ErrResponsibleNotFound)
+	}
+
+	return nil
+}
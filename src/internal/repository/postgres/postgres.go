@@ -2,25 +2,45 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/domain/models"
 	"git.codenrock.com/avito/internal/repository"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
-	"time"
+	"strings"
 )
 
 type Storage struct {
 	db *pgxpool.Pool
+
+	// searchConfigs are the text search configurations tender search queries are
+	// parsed with.
+	searchConfigs []string
+}
+
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 }
 
 const (
-	DecisionApproved = "Approved"
-	DecisionRejected = "Rejected"
+	uniqueViolation     = "23505"
+	foreignKeyViolation = "23503"
+	deadlockDetected    = "40P01"
 )
 
-func New(conn string) (*Storage, error) {
+// isPgError reports whether err is a PostgreSQL error with the given SQLSTATE code.
+func isPgError(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
+
+func New(conn string, searchConfigs []string) (*Storage, error) {
 	const op = "storage.postgres.New"
 
 	db, err := pgxpool.New(context.Background(), conn)
@@ -29,619 +49,1089 @@ func New(conn string) (*Storage, error) {
 	}
 
 	return &Storage{
-		db: db,
+		db:            db,
+		searchConfigs: searchConfigs,
 	}, nil
 }
 
-func (s *Storage) GetTenders(ctx context.Context, serviceTypes []string, limit, offset int) ([]dto.TenderResponseDTO, error) {
+// searchQuery returns a tsquery matching search in every configured text search
+// configuration and the configuration to highlight snippets with. A configuration the
+// database does not know falls back to 'simple', which is always matched as well so
+// that words no stemmer recognises are still found.
+func (s *Storage) searchQuery(b *listBuilder, search string) (tsQuery, headlineConfig string) {
+	query := b.arg(search)
+
+	headlineConfig = "'simple'::regconfig"
+	parts := make([]string, 0, len(s.searchConfigs)+1)
+	for i, name := range s.searchConfigs {
+		config := "COALESCE(to_regconfig(" + b.arg(name) + "), 'simple')"
+		if i == 0 {
+			headlineConfig = config
+		}
+		parts = append(parts, "websearch_to_tsquery("+config+", "+query+")")
+	}
+	parts = append(parts, "websearch_to_tsquery('simple', "+query+")")
+
+	return "(" + strings.Join(parts, " || ") + ")", headlineConfig
+}
+
+// GetTenders lists published tenders matching the query: public ones, and invite-only
+// ones of or inviting an organization the viewer is responsible for. An anonymous
+// viewer is passed as an empty string. With a search query the results are ordered by
+// relevance unless a sort is given, and carry a highlighted snippet.
+func (s *Storage) GetTenders(ctx context.Context, viewer string, q dto.ListQueryDTO) (dto.PageDTO[dto.TenderResponseDTO], error) {
 	const op = "storage.postgres.GetTenders"
 
-	query := `SELECT id, name, description, status, service_type, version, created_at 
-              FROM tenders WHERE status = 'PUBLISHED'`
+	var b listBuilder
+	b.where("status = %s", models.TenderStatusPublished)
+	if viewer == "" {
+		b.where("visibility = %s", models.TenderVisibilityPublic)
+	} else {
+		b.conditions = append(b.conditions, `(visibility = `+b.arg(models.TenderVisibilityPublic)+` OR EXISTS (
+			SELECT 1 FROM organization_responsible r JOIN employee e ON e.id = r.user_id
+			WHERE e.username = `+b.arg(viewer)+` AND (r.organization_id = tenders.organization_id
+				OR r.organization_id IN (SELECT i.organization_id FROM tender_invitations i WHERE i.tender_id = tenders.id))))`)
+	}
+
+	search := q.Query
+	q.Query = ""
 
-	var args []interface{}
-	argIndex := 1
+	rank, snippet := "0::real", "''"
+	if search != "" {
+		tsQuery, headlineConfig := s.searchQuery(&b, search)
+		b.conditions = append(b.conditions, "search_vector @@ "+tsQuery)
+		rank = "ts_rank(search_vector, " + tsQuery + ")"
+		snippet = "ts_headline(" + headlineConfig + ", name || ' ' || COALESCE(description, ''), " + tsQuery +
+			", 'StartSel=<b>, StopSel=</b>, MaxFragments=2, MaxWords=20, MinWords=5')"
+	}
 
-	if len(serviceTypes) > 0 {
-		query += ` WHERE service_type = ANY($` + fmt.Sprint(argIndex) + `)`
-		args = append(args, serviceTypes)
-		argIndex++
+	order, err := tenderListSpec.apply(&b, q)
+	if err != nil {
+		return dto.PageDTO[dto.TenderResponseDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if search != "" && len(q.Sort) == 0 {
+		order = listOrder{sql: "rank DESC, " + order.sql}
 	}
 
-	query += ` ORDER BY created_at DESC LIMIT $` + fmt.Sprint(argIndex) + ` OFFSET $` + fmt.Sprint(argIndex+1)
-	args = append(args, limit, offset)
+	total, err := s.countRows(ctx, "tenders", &b)
+	if err != nil {
+		return dto.PageDTO[dto.TenderResponseDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tenderListSpec.seek(&b, order, q.After); err != nil {
+		return dto.PageDTO[dto.TenderResponseDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	query := `SELECT ` + tenderColumns + `, ` + rank + ` AS rank, ` + snippet + ` AS snippet
+		FROM tenders` + b.whereClause() + `
+		ORDER BY ` + order.sql + `
+		` + b.page(q)
 
-	rows, err := s.db.Query(ctx, query, args...)
+	rows, err := s.conn(ctx).Query(ctx, query, b.args...)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return dto.PageDTO[dto.TenderResponseDTO]{}, fmt.Errorf("%s: %w", op, err)
 	}
 	defer rows.Close()
 
-	var tenders []dto.TenderResponseDTO
+	tenders := []dto.TenderResponseDTO{}
 	for rows.Next() {
 		var tender dto.TenderResponseDTO
-		if err := rows.Scan(&tender.ID, &tender.Name, &tender.Description, &tender.Status, &tender.ServiceType, &tender.Version, &tender.CreatedAt); err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+		err = rows.Scan(&tender.ID, &tender.Name, &tender.Description, &tender.Status, &tender.ServiceType,
+			&tender.OrganizationID, &tender.CreatorUsername, &tender.Version, &tender.CreatedAt, &tender.UpdatedAt,
+			&tender.SubmissionDeadline, &tender.DecisionDeadline, &tender.PublishAt,
+			&tender.BudgetMin, &tender.BudgetMax, &tender.Currency, &tender.VATIncluded, &tender.CapAtBudget,
+			&tender.Visibility, &tender.Sealed, &tender.InvitedOrganizations, &tender.Rank, &tender.Snippet)
+		if err != nil {
+			return dto.PageDTO[dto.TenderResponseDTO]{}, fmt.Errorf("%s: %w", op, err)
 		}
 		tenders = append(tenders, tender)
 	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+	if err = rows.Err(); err != nil {
+		return dto.PageDTO[dto.TenderResponseDTO]{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return tenders, nil
+	return tenderPage(tenders, total, order, q.Limit), nil
+}
+
+// tenderPage wraps one page of tenders with the cursor of the next page.
+func tenderPage(tenders []dto.TenderResponseDTO, total int, order listOrder, limit int) dto.PageDTO[dto.TenderResponseDTO] {
+	return dto.PageDTO[dto.TenderResponseDTO]{
+		Items: tenders,
+		Total: total,
+		NextCursor: nextCursor(order, limit, len(tenders), func() dto.Cursor {
+			last := tenders[len(tenders)-1]
+			return dto.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+		}),
+	}
 }
 
 func (s *Storage) CreateTender(ctx context.Context, tender dto.TenderDTO) (dto.TenderResponseDTO, error) {
 	const op = "storage.postgres.CreateTender"
 
-	query := `INSERT INTO tenders (name, description, status, service_type, organization_id, creator_username, version, created_at, updated_at)
-			  VALUES ($1, $2, 'Created', $3, $4, $5, 1, NOW(), NOW()) RETURNING id, created_at`
-	var tenderID uuid.UUID
-	var createdAt time.Time
-	var version int
-	err := s.db.QueryRow(ctx, query, tender.Name, tender.Description, tender.ServiceType, tender.Status, tender.OrganizationID, tender.CreatorUsername).Scan(&tenderID, &createdAt, &version)
+	tx, err := s.begin(ctx)
 	if err != nil {
 		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
+	defer tx.Rollback(ctx)
 
+	query := `INSERT INTO tenders (name, description, status, service_type, organization_id, creator_username, updated_by, version, created_at, updated_at,
+					submission_deadline, decision_deadline, publish_at, budget_min, budget_max, currency, vat_included, cap_at_budget, visibility, sealed)
+			  VALUES ($1, $2, $3, $4, $5, $6, $6, 1, NOW(), NOW(), $7, $8, $9, $10::text::numeric, $11::text::numeric, NULLIF($12, ''), $13, $14, $15, $16)
+			  RETURNING id, version, created_at, updated_at`
 	newTender := dto.TenderResponseDTO{
-		ID:          tenderID,
-		Name:        tender.Name,
-		Description: tender.Description,
-		Status:      tender.Status,
-		ServiceType: tender.ServiceType,
-		Version:     version,
-		CreatedAt:   createdAt,
+		Name:            tender.Name,
+		Description:     tender.Description,
+		Status:          models.TenderStatusCreated.String(),
+		ServiceType:     tender.ServiceType,
+		OrganizationID:  tender.OrganizationID,
+		CreatorUsername: tender.CreatorUsername,
+
+		SubmissionDeadline: tender.SubmissionDeadline,
+		DecisionDeadline:   tender.DecisionDeadline,
+		PublishAt:          tender.PublishAt,
+
+		BudgetMin:   tender.BudgetMin,
+		BudgetMax:   tender.BudgetMax,
+		Currency:    tender.Currency,
+		VATIncluded: tender.VATIncluded,
+		CapAtBudget: tender.CapAtBudget,
+
+		Visibility:           tender.Visibility,
+		Sealed:               tender.Sealed,
+		InvitedOrganizations: []uuid.UUID{},
+	}
+	err = tx.QueryRow(ctx, query, tender.Name, tender.Description, newTender.Status, tender.ServiceType, tender.OrganizationID, tender.CreatorUsername,
+		tender.SubmissionDeadline, tender.DecisionDeadline, tender.PublishAt,
+		tender.BudgetMin, tender.BudgetMax, tender.Currency, tender.VATIncluded, tender.CapAtBudget, tender.Visibility, tender.Sealed).Scan(
+		&newTender.ID, &newTender.Version, &newTender.CreatedAt, &newTender.UpdatedAt)
+	if err != nil {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
+
+	if err = recordTenderTransition(ctx, tx, newTender.ID, "", models.TenderStatusCreated, tender.CreatorUsername); err != nil {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(tender.Lots) > 0 {
+		if newTender.Lots, err = insertLots(ctx, tx, newTender.ID, tender.Lots); err != nil {
+			return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	return newTender, nil
 }
 
-func (s *Storage) GetUserTenders(ctx context.Context, username string, limit, offset int) ([]dto.TenderResponseDTO, error) {
+func (s *Storage) GetUserTenders(ctx context.Context, username string, q dto.ListQueryDTO) (dto.PageDTO[dto.TenderResponseDTO], error) {
 	const op = "storage.postgres.GetUserTenders"
 
-	query := `SELECT id, name, description, status, service_type, version, created_at 
-              FROM tenders WHERE creator_username = $1`
+	var b listBuilder
+	b.where("creator_username = %s", username)
 
-	query += ` ORDER BY created_at DESC LIMIT $2 OFFSET $3`
+	order, err := tenderListSpec.apply(&b, q)
+	if err != nil {
+		return dto.PageDTO[dto.TenderResponseDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
 
-	rows, err := s.db.Query(ctx, query, username, limit, offset)
+	total, err := s.countRows(ctx, "tenders", &b)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return dto.PageDTO[dto.TenderResponseDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tenderListSpec.seek(&b, order, q.After); err != nil {
+		return dto.PageDTO[dto.TenderResponseDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.conn(ctx).Query(ctx, `SELECT `+tenderColumns+`
+		FROM tenders`+b.whereClause()+`
+		ORDER BY `+order.sql+`
+		`+b.page(q), b.args...)
+	if err != nil {
+		return dto.PageDTO[dto.TenderResponseDTO]{}, fmt.Errorf("%s: %w", op, err)
 	}
 	defer rows.Close()
 
-	var tenders []dto.TenderResponseDTO
+	tenders := []dto.TenderResponseDTO{}
 	for rows.Next() {
-		var tender dto.TenderResponseDTO
-		if err = rows.Scan(&tender.ID, &tender.Name, &tender.Description, &tender.Status, &tender.ServiceType, &tender.Version, &tender.CreatedAt); err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+		tender, err := scanTender(rows)
+		if err != nil {
+			return dto.PageDTO[dto.TenderResponseDTO]{}, fmt.Errorf("%s: %w", op, err)
 		}
 		tenders = append(tenders, tender)
 	}
-	return tenders, nil
+	if err = rows.Err(); err != nil {
+		return dto.PageDTO[dto.TenderResponseDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tenderPage(tenders, total, order, q.Limit), nil
 }
 
-func (s *Storage) GetTenderStatus(ctx context.Context, tenderID uuid.UUID, username string) (string, error) {
-	query := `SELECT status, organization_id FROM tenders WHERE id = $1`
-	var status string
-	var organizationID string
-	err := s.db.QueryRow(ctx, query, tenderID).Scan(&status, &organizationID)
+func (s *Storage) GetTender(ctx context.Context, tenderID uuid.UUID) (dto.TenderResponseDTO, error) {
+	const op = "storage.postgres.GetTender"
+
+	tender, err := s.getTender(ctx, tenderID, "")
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return "", fmt.Errorf("tender not found")
-		}
-		return "", err
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if status != "PUBLISHED" {
-		isResponsible, err := s.IsUserResponsibleForOrganization(ctx, username, organizationID)
-		if err != nil {
-			return "", err
-		}
+	return tender, nil
+}
+
+// LockTender reads the tender and locks its row until the end of the surrounding
+// transaction, so status checks made on it stay valid until the transaction ends.
+func (s *Storage) LockTender(ctx context.Context, tenderID uuid.UUID) (dto.TenderResponseDTO, error) {
+	const op = "storage.postgres.LockTender"
 
-		if !isResponsible {
-			return "", fmt.Errorf("user is not responsible for the organization")
+	tender, err := s.getTender(ctx, tenderID, " FOR UPDATE")
+	if err != nil {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tender, nil
+}
+
+func (s *Storage) getTender(ctx context.Context, tenderID uuid.UUID, lock string) (dto.TenderResponseDTO, error) {
+	tender, err := scanTender(s.conn(ctx).QueryRow(ctx, `SELECT `+tenderColumns+` FROM tenders WHERE id = $1`+lock, tenderID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.TenderResponseDTO{}, repository.ErrTenderNotFound
 		}
+		return dto.TenderResponseDTO{}, err
 	}
 
-	return status, nil
+	return tender, nil
 }
 
-func (s *Storage) UpdateTenderStatus(ctx context.Context, tenderID uuid.UUID, newStatus, username string) (dto.TenderResponseDTO, error) {
+// UpdateTenderStatus moves the tender from one status to another. The update only
+// applies while the tender is still in the from status, so concurrent transitions
+// validated against a stale status are rejected.
+func (s *Storage) UpdateTenderStatus(ctx context.Context, tenderID uuid.UUID, from, to models.TenderStatus, username string) (dto.TenderResponseDTO, error) {
 	const op = "storage.postgres.UpdateTenderStatus"
 
-	query := `UPDATE tenders SET status = $1, updated_at = NOW() WHERE id = $2 AND creator_username = $3`
-	result, err := s.db.Exec(ctx, query, newStatus, tenderID, username)
+	tx, err := s.begin(ctx)
 	if err != nil {
 		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
-	if result.RowsAffected() == 0 {
-		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrUserIsNotCreatorOrTenderWasNotFound)
+	defer tx.Rollback(ctx)
+
+	if err = lockTender(ctx, tx, tenderID); err != nil {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	query = `SELECT id, name, description, status, service_type, version, created_at FROM tenders WHERE id = $1`
-	var tender dto.TenderResponseDTO
-	err = s.db.QueryRow(ctx, query, tenderID).Scan(&tender.ID, &tender.Name, &tender.Description, &tender.Status, &tender.ServiceType, &tender.Version, &tender.CreatedAt)
+	if err = snapshotTender(ctx, tx, tenderID); err != nil {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	query := `UPDATE tenders SET status = $1, version = version + 1, updated_by = $4, updated_at = NOW() WHERE id = $2 AND status = $3
+			  RETURNING ` + tenderColumns
+	tender, err := scanTender(tx.QueryRow(ctx, query, to, tenderID, from, username))
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrIllegalTenderTransition)
+		}
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = recordTenderTransition(ctx, tx, tenderID, from, to, username); err != nil {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
 		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return tender, nil
 }
 
+func (s *Storage) GetTenderStatusHistory(ctx context.Context, tenderID uuid.UUID) ([]dto.TenderStatusChangeDTO, error) {
+	const op = "storage.postgres.GetTenderStatusHistory"
+
+	rows, err := s.conn(ctx).Query(ctx, `SELECT COALESCE(from_status, ''), to_status, actor_username, created_at
+		FROM tender_status_history
+		WHERE tender_id = $1
+		ORDER BY created_at ASC`, tenderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	history := []dto.TenderStatusChangeDTO{}
+	for rows.Next() {
+		var change dto.TenderStatusChangeDTO
+		if err = rows.Scan(&change.FromStatus, &change.ToStatus, &change.Actor, &change.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		history = append(history, change)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return history, nil
+}
+
+func (s *Storage) HasApprovedBids(ctx context.Context, tenderID uuid.UUID) (bool, error) {
+	const op = "storage.postgres.HasApprovedBids"
+
+	var approved bool
+	err := s.conn(ctx).QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM bids WHERE tender_id = $1 AND status = $2)`, tenderID, models.BidStatusApproved).Scan(&approved)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return approved, nil
+}
+
 func (s *Storage) UpdateTenderInfo(ctx context.Context, tenderID uuid.UUID, updatedData dto.UpdateTenderDTO, username string) (dto.TenderResponseDTO, error) {
 	const op = "storage.postgres.UpdateTenderInfo"
 
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err = lockTender(ctx, tx, tenderID); err != nil {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = snapshotTender(ctx, tx, tenderID); err != nil {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	query := `UPDATE tenders SET name = COALESCE(NULLIF($1, ''), name), 
 								description = COALESCE(NULLIF($2, ''), description), 
 								service_type = COALESCE(NULLIF($3, ''), service_type), 
+								submission_deadline = CASE WHEN $16 THEN $6::timestamptz ELSE submission_deadline END,
+								decision_deadline = CASE WHEN $17 THEN $7::timestamptz ELSE decision_deadline END,
+								publish_at = CASE WHEN $18 THEN $8::timestamptz ELSE publish_at END,
+								budget_min = COALESCE($9::text::numeric, budget_min),
+								budget_max = COALESCE($10::text::numeric, budget_max),
+								currency = COALESCE(NULLIF($11, ''), currency),
+								vat_included = COALESCE($12, vat_included),
+								cap_at_budget = COALESCE($13, cap_at_budget),
+								visibility = COALESCE(NULLIF($14, ''), visibility),
+								sealed = COALESCE($15, sealed),
 								version = version + 1, 
+								updated_by = $5,
 								updated_at = NOW() 
-			  WHERE id = $4 AND creator_username = $5 RETURNING id, name, description, service_type, status, version, created_at`
+			  WHERE id = $4 RETURNING ` + tenderColumns
 
-	var updatedTender dto.TenderResponseDTO
-	err := s.db.QueryRow(ctx, query, updatedData.Name, updatedData.Description, updatedData.ServiceType, tenderID, username).Scan(
-		&updatedTender.ID, &updatedTender.Name, &updatedTender.Description, &updatedTender.ServiceType, &updatedTender.Status, &updatedTender.Version, &updatedTender.CreatedAt)
+	updatedTender, err := scanTender(tx.QueryRow(ctx, query, updatedData.Name, updatedData.Description, updatedData.ServiceType, tenderID, username,
+		updatedData.SubmissionDeadline.Time, updatedData.DecisionDeadline.Time, updatedData.PublishAt.Time,
+		updatedData.BudgetMin, updatedData.BudgetMax, updatedData.Currency, updatedData.VATIncluded, updatedData.CapAtBudget, updatedData.Visibility, updatedData.Sealed,
+		updatedData.SubmissionDeadline.Set, updatedData.DecisionDeadline.Set, updatedData.PublishAt.Set))
 	if err != nil {
 		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
+	if err = tx.Commit(ctx); err != nil {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	return updatedTender, nil
 }
 
+// RollbackTenderVersion restores the name, description and service type of an earlier
+// version as a new version. The status is left alone: it only changes through the
+// status transitions.
 func (s *Storage) RollbackTenderVersion(ctx context.Context, tenderID uuid.UUID, version int, username string) (dto.TenderResponseDTO, error) {
 	const op = "storage.postgres.RollbackTenderVersion"
 
-	var rollbackTender dto.TenderResponseDTO
-	query := `SELECT name, description, service_type, status FROM tender_versions WHERE tender_id = $1 AND version = $2`
-	err := s.db.QueryRow(ctx, query, tenderID, version).Scan(&rollbackTender.Name, &rollbackTender.Description, &rollbackTender.ServiceType, &rollbackTender.Status)
+	tx, err := s.begin(ctx)
 	if err != nil {
 		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
+	defer tx.Rollback(ctx)
 
-	updateQuery := `UPDATE tenders SET name = $1, description = $2, service_type = $3, status = $4, version = version + 1, updated_at = NOW() 
-					WHERE id = $5 AND creator_username = $6 RETURNING id, name, description, service_type, status, version, created_at`
+	if err = lockTender(ctx, tx, tenderID); err != nil {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
 
-	err = s.db.QueryRow(ctx, updateQuery, rollbackTender.Name, rollbackTender.Description, rollbackTender.ServiceType, rollbackTender.Status, tenderID, username).Scan(
-		&rollbackTender.ID, &rollbackTender.Name, &rollbackTender.Description, &rollbackTender.ServiceType, &rollbackTender.Status, &rollbackTender.Version, &rollbackTender.CreatedAt)
+	var rollbackTender dto.UpdateTenderDTO
+	query := `SELECT name, COALESCE(description, ''), COALESCE(service_type, '') FROM tender_versions WHERE tender_id = $1 AND version = $2`
+	err = tx.QueryRow(ctx, query, tenderID, version).Scan(&rollbackTender.Name, &rollbackTender.Description, &rollbackTender.ServiceType)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrVersionNotFound)
+		}
 		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return rollbackTender, nil
+	if err = snapshotTender(ctx, tx, tenderID); err != nil {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	updateQuery := `UPDATE tenders SET name = $1, description = $2, service_type = $3, version = version + 1, updated_by = $5, updated_at = NOW() 
+					WHERE id = $4 RETURNING ` + tenderColumns
+
+	tender, err := scanTender(tx.QueryRow(ctx, updateQuery, rollbackTender.Name, rollbackTender.Description, rollbackTender.ServiceType, tenderID, username))
+	if err != nil {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tender, nil
 }
 
 // Bids methods ---------------------------------------------------------------
 
+// CreateBid inserts a bid on a published tender. The tender row stays share-locked
+// until the bid is committed, so the tender cannot be closed in between.
 func (s *Storage) CreateBid(ctx context.Context, bid *dto.BidDTO) (dto.BidResponseDTO, error) {
 	const op = "storage.postgres.CreateBid"
 
-	var tenderExists bool
-	err := s.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM tenders WHERE id = $1)`, bid.TenderID).Scan(&tenderExists)
+	tx, err := s.begin(ctx)
 	if err != nil {
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
-	if !tenderExists {
-		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrTenderNotFound)
-	}
+	defer tx.Rollback(ctx)
 
-	var organizationExists bool
-	err = s.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM organization WHERE id = $1)`, bid.AuthorID).Scan(&organizationExists)
+	var tenderID uuid.UUID
+	var status models.TenderStatus
+	var visibility models.TenderVisibility
+	err = tx.QueryRow(ctx, `SELECT id, status, visibility FROM tenders WHERE id = $1 FOR SHARE`, bid.TenderID).Scan(&tenderID, &status, &visibility)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrTenderNotFound)
+		}
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
-	if !organizationExists {
-		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrOrganizationNotFound)
+	if status != models.TenderStatusPublished {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrTenderNotPublished)
 	}
 
-	if bid.AuthorType == "User" {
-		var userInOrganization bool
-		err = s.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM organization_responsible 
-                                                WHERE organization_id = $1 
-                                                AND user_id = $2)`,
-			bid.AuthorID, bid.AuthorID).Scan(&userInOrganization)
+	var organizationID uuid.UUID
+	switch bid.AuthorType {
+	case "Organization":
+		err = tx.QueryRow(ctx, `SELECT id FROM organization WHERE id = $1`, bid.AuthorID).Scan(&organizationID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrOrganizationNotFound)
+		}
+	case "User":
+		organizationID, err = bidOrganization(ctx, tx, bid, tenderID, visibility == models.TenderVisibilityInviteOnly)
+	default:
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrInvalidAuthorType)
+	}
+	if err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if visibility == models.TenderVisibilityInviteOnly {
+		var invited bool
+		err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM tender_invitations WHERE tender_id = $1 AND organization_id = $2)`,
+			tenderID, organizationID).Scan(&invited)
 		if err != nil {
 			return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 		}
-		if !userInOrganization {
-			return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrNoAssociationWithOrganization)
+		if !invited {
+			return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrOrganizationNotInvited)
 		}
 	}
 
-	var id uuid.UUID
-	err = s.db.QueryRow(ctx, `INSERT INTO bids (name, description, tender_id, organization_id, author_type, author_id, status, version, created_at) 
-	VALUES ($1, $2, $3, $4, $5, $6, 'CREATED', 1, NOW()) 
+	var bidID uuid.UUID
+	err = tx.QueryRow(ctx, `INSERT INTO bids (name, description, tender_id, organization_id, author_type, author_id, status, updated_by, version, created_at, updated_at,
+		price, currency)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, (SELECT username FROM employee WHERE id = $6), 1, NOW(), NOW(), $8::text::numeric, NULLIF($9, ''))
 	RETURNING id`,
-		bid.Name, bid.Description, bid.TenderID, bid.AuthorID, bid.AuthorType, bid.AuthorID).Scan(&id)
+		bid.Name, bid.Description, bid.TenderID, organizationID, bid.AuthorType, bid.AuthorID, models.BidStatusCreated,
+		bid.Price, bid.Currency).Scan(&bidID)
 	if err != nil {
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	var createdBid dto.BidResponseDTO
-	err = s.db.QueryRow(ctx, `SELECT id, name, status, author_type, author_id, version, created_at 
-		FROM bids WHERE id = $1`, id).Scan(
-		&createdBid.ID,
-		&createdBid.Name,
-		&createdBid.Status,
-		&createdBid.AuthorType,
-		&createdBid.AuthorID,
-		&createdBid.Version,
-		&createdBid.CreatedAt,
-	)
+	if err = attachBidLots(ctx, tx, bid.TenderID, bidID, bid.LotIDs); err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i, item := range bid.Items {
+		_, err = tx.Exec(ctx, `INSERT INTO bid_items (bid_id, position, name, quantity, unit_price)
+			VALUES ($1, $2, $3, $4::text::numeric, $5::text::numeric)`, bidID, i+1, item.Name, item.Quantity, item.UnitPrice)
+		if err != nil {
+			return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	createdBid, err := scanBid(tx.QueryRow(ctx, `SELECT `+bidColumns+` FROM bids WHERE id = $1`, bidID))
 	if err != nil {
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
+	if err = tx.Commit(ctx); err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	return createdBid, nil
 }
 
-func (s *Storage) GetBidsByUsername(ctx context.Context, username string, limit, offset int) ([]dto.BidResponseDTO, error) {
-	query := `SELECT id, name, status, author_type, author_id, version, created_at FROM bids WHERE author_id = (SELECT id FROM employee WHERE username = $1) ORDER BY name ASC LIMIT $2 OFFSET $3`
-
-	rows, err := s.db.Query(ctx, query, username, limit, offset)
+// bidOrganization resolves the organization an employee bids for: the one given in
+// the bid, or otherwise their only organization. On an invite-only tender only the
+// invited organizations of the employee are considered.
+func bidOrganization(ctx context.Context, q querier, bid *dto.BidDTO, tenderID uuid.UUID, inviteOnly bool) (uuid.UUID, error) {
+	rows, err := q.Query(ctx, `SELECT r.organization_id,
+			EXISTS (SELECT 1 FROM tender_invitations i WHERE i.tender_id = $2 AND i.organization_id = r.organization_id)
+		FROM organization_responsible r
+		WHERE r.user_id = $1 AND ($3::uuid IS NULL OR r.organization_id = $3)`,
+		bid.AuthorID, tenderID, bid.OrganizationID)
 	if err != nil {
-		return nil, err
+		return uuid.Nil, err
 	}
-	defer rows.Close()
 
-	var bids []dto.BidResponseDTO
+	var member, candidates []uuid.UUID
 	for rows.Next() {
-		var bid dto.BidResponseDTO
-		err = rows.Scan(&bid.ID, &bid.Name, &bid.Status, &bid.AuthorType, &bid.AuthorID, &bid.Version, &bid.CreatedAt)
-		if err != nil {
-			return nil, err
+		var id uuid.UUID
+		var invited bool
+		if err = rows.Scan(&id, &invited); err != nil {
+			rows.Close()
+			return uuid.Nil, err
+		}
+		member = append(member, id)
+		if invited || !inviteOnly {
+			candidates = append(candidates, id)
 		}
-		bids = append(bids, bid)
 	}
-	return bids, nil
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return uuid.Nil, err
+	}
+
+	switch {
+	case len(member) == 0:
+		return uuid.Nil, repository.ErrNoAssociationWithOrganization
+	case len(candidates) == 0:
+		return uuid.Nil, repository.ErrOrganizationNotInvited
+	case len(candidates) > 1:
+		return uuid.Nil, repository.ErrAmbiguousOrganization
+	}
+	return candidates[0], nil
 }
 
-func (s *Storage) GetTenderBids(ctx context.Context, tenderID string, limit, offset int) ([]dto.BidResponseDTO, error) {
+func (s *Storage) GetBid(ctx context.Context, bidID uuid.UUID) (models.Bid, error) {
+	const op = "storage.postgres.GetBid"
 
-	query := `SELECT id, name, status, author_type, author_id, version, created_at 
-              FROM bids WHERE tender_id = $1 ORDER BY name ASC LIMIT $2 OFFSET $3`
-	rows, err := s.db.Query(ctx, query, tenderID, limit, offset)
+	bid, err := s.getBid(ctx, bidID, "")
 	if err != nil {
-		return nil, err
+		return models.Bid{}, fmt.Errorf("%s: %w", op, err)
 	}
-	defer rows.Close()
 
-	var bids []dto.BidResponseDTO
-	for rows.Next() {
-		var bid dto.BidResponseDTO
-		if err = rows.Scan(&bid.ID, &bid.Name, &bid.Status, &bid.AuthorType, &bid.AuthorID, &bid.Version, &bid.CreatedAt); err != nil {
-			return nil, err
+	return bid, nil
+}
+
+// LockBid reads the bid and locks its row until the end of the surrounding
+// transaction, so status checks made on it stay valid until the transaction ends.
+func (s *Storage) LockBid(ctx context.Context, bidID uuid.UUID) (models.Bid, error) {
+	const op = "storage.postgres.LockBid"
+
+	bid, err := s.getBid(ctx, bidID, " FOR UPDATE")
+	if err != nil {
+		return models.Bid{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return bid, nil
+}
+
+func (s *Storage) getBid(ctx context.Context, bidID uuid.UUID, lock string) (models.Bid, error) {
+	var bid models.Bid
+	err := s.conn(ctx).QueryRow(ctx, `SELECT id, name, COALESCE(description, ''), status, tender_id, organization_id, COALESCE(author_type, ''), author_id, version, created_at, updated_at
+		FROM bids WHERE id = $1`+lock, bidID).Scan(
+		&bid.ID,
+		&bid.Name,
+		&bid.Description,
+		&bid.Status,
+		&bid.TenderID,
+		&bid.OrganizationID,
+		&bid.AuthorType,
+		&bid.AuthorID,
+		&bid.Version,
+		&bid.CreatedAt,
+		&bid.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Bid{}, repository.ErrBidNotFound
 		}
-		bids = append(bids, bid)
+		return models.Bid{}, err
+	}
+
+	return bid, nil
+}
+
+func (s *Storage) GetBidsByUsername(ctx context.Context, username string, q dto.ListQueryDTO) (dto.PageDTO[dto.BidResponseDTO], error) {
+	const op = "storage.postgres.GetBidsByUsername"
+
+	var b listBuilder
+	b.where("author_id = (SELECT id FROM employee WHERE username = %s)", username)
+
+	bids, err := s.listBids(ctx, &b, q)
+	if err != nil {
+		return dto.PageDTO[dto.BidResponseDTO]{}, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return bids, nil
 }
 
-func (s *Storage) GetBidStatus(ctx context.Context, bidID uuid.UUID, username string) (string, error) {
-	const op = "storage.postgres.GetBidStatus"
+func (s *Storage) GetTenderBids(ctx context.Context, tenderID uuid.UUID, q dto.ListQueryDTO) (dto.PageDTO[dto.BidResponseDTO], error) {
+	const op = "storage.postgres.GetTenderBids"
+
+	var b listBuilder
+	b.where("tender_id = %s", tenderID)
 
-	var status string
-	err := s.db.QueryRow(ctx, `SELECT status FROM bids WHERE id = $1`, bidID).Scan(&status)
+	bids, err := s.listBids(ctx, &b, q)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return "", fmt.Errorf("%s: %w", op, repository.ErrBidNotFound)
-		}
-		return "", fmt.Errorf("%s: %w", op, err)
+		return dto.PageDTO[dto.BidResponseDTO]{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	var userAuthorized bool
-	err = s.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM organization_responsible 
-                                                WHERE organization_id = (SELECT organization_id FROM bids WHERE id = $1) 
-                                                AND user_id = (SELECT id FROM employee WHERE username = $2))`,
-		bidID, username).Scan(&userAuthorized)
+	return bids, nil
+}
+
+func (s *Storage) listBids(ctx context.Context, b *listBuilder, q dto.ListQueryDTO) (dto.PageDTO[dto.BidResponseDTO], error) {
+	order, err := bidListSpec.apply(b, q)
+	if err != nil {
+		return dto.PageDTO[dto.BidResponseDTO]{}, err
+	}
+
+	total, err := s.countRows(ctx, "bids", b)
 	if err != nil {
-		return "", fmt.Errorf("%s: %w", op, err)
+		return dto.PageDTO[dto.BidResponseDTO]{}, err
 	}
-	if !userAuthorized {
-		return "", fmt.Errorf("%s: %w", op, repository.ErrNoPermission)
+	if err = bidListSpec.seek(b, order, q.After); err != nil {
+		return dto.PageDTO[dto.BidResponseDTO]{}, err
 	}
 
-	return status, nil
+	rows, err := s.conn(ctx).Query(ctx, `SELECT `+bidColumns+`
+		FROM bids`+b.whereClause()+`
+		ORDER BY `+order.sql+`
+		`+b.page(q), b.args...)
+	if err != nil {
+		return dto.PageDTO[dto.BidResponseDTO]{}, err
+	}
+	defer rows.Close()
+
+	bids := []dto.BidResponseDTO{}
+	for rows.Next() {
+		bid, err := scanBid(rows)
+		if err != nil {
+			return dto.PageDTO[dto.BidResponseDTO]{}, err
+		}
+		bids = append(bids, bid)
+	}
+	if err = rows.Err(); err != nil {
+		return dto.PageDTO[dto.BidResponseDTO]{}, err
+	}
+
+	return dto.PageDTO[dto.BidResponseDTO]{
+		Items: bids,
+		Total: total,
+		NextCursor: nextCursor(order, q.Limit, len(bids), func() dto.Cursor {
+			last := bids[len(bids)-1]
+			return dto.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+		}),
+	}, nil
 }
 
-func (s *Storage) UpdateBidStatus(ctx context.Context, bidID uuid.UUID, status string, username string) (dto.BidResponseDTO, error) {
+// UpdateBidStatus applies an author-side transition. The update only applies while
+// the bid is still in the from status, so a bid decided in the meantime is not
+// overwritten.
+func (s *Storage) UpdateBidStatus(ctx context.Context, bidID uuid.UUID, from, to models.BidStatus, username string) (dto.BidResponseDTO, error) {
 	const op = "repository.postgres.UpdateBidStatus"
 
-	var exists bool
-	err := s.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM bids WHERE id = $1)`, bidID).Scan(&exists)
+	tx, err := s.begin(ctx)
 	if err != nil {
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
-	if !exists {
-		return dto.BidResponseDTO{}, repository.ErrBidNotFound
-	}
+	defer tx.Rollback(ctx)
 
-	var userIsResponsible bool
-	err = s.db.QueryRow(ctx, `
-		SELECT EXISTS(
-			SELECT 1 
-			FROM organization_responsible
-			WHERE user_id = (SELECT id FROM employee WHERE username = $1)
-			AND organization_id = (SELECT organization_id FROM bids WHERE id = $2)
-		)`, username, bidID).Scan(&userIsResponsible)
-	if err != nil {
+	if err = lockBid(ctx, tx, bidID); err != nil {
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
-	if !userIsResponsible {
-		return dto.BidResponseDTO{}, repository.ErrNoPermission
+
+	if err = snapshotBid(ctx, tx, bidID); err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	_, err = s.db.Exec(ctx, `UPDATE bids SET status = $1 WHERE id = $2`, status, bidID)
+	bid, err := scanBid(tx.QueryRow(ctx, `UPDATE bids SET status = $1, version = version + 1, updated_by = $4, updated_at = NOW()
+		WHERE id = $2 AND status = $3
+		RETURNING `+bidColumns, to, bidID, from, username))
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrIllegalBidTransition)
+		}
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
-	var Bid dto.BidResponseDTO
-	err = s.db.QueryRow(ctx, `SELECT id, name, status, author_type, author_id, version, created_at FROM bids WHERE id = $1`, bidID).Scan(
-		&Bid.ID,
-		&Bid.Name,
-		&Bid.Status,
-		&Bid.AuthorType,
-		&Bid.AuthorID,
-		&Bid.Version,
-		&Bid.CreatedAt,
-	)
-	if err != nil {
+
+	if err = tx.Commit(ctx); err != nil {
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return Bid, nil
+	return bid, nil
 }
 
 func (s *Storage) UpdateBid(ctx context.Context, bidID uuid.UUID, username string, updates dto.UpdateBidDTO) (dto.BidResponseDTO, error) {
 	const op = "repository.postgres.UpdateBid"
 
-	var exists bool
-	err := s.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM bids WHERE id = $1)`, bidID).Scan(&exists)
+	tx, err := s.begin(ctx)
 	if err != nil {
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
-	if !exists {
-		return dto.BidResponseDTO{}, repository.ErrBidNotFound
-	}
+	defer tx.Rollback(ctx)
 
-	var userIsResponsible bool
-	err = s.db.QueryRow(ctx, `
-		SELECT EXISTS(
-			SELECT 1 
-			FROM organization_responsible
-			WHERE user_id = (SELECT id FROM employee WHERE username = $1)
-			AND organization_id = (SELECT organization_id FROM bids WHERE id = $2)
-		)`, username, bidID).Scan(&userIsResponsible)
-	if err != nil {
+	if err = lockBid(ctx, tx, bidID); err != nil {
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
-	if !userIsResponsible {
-		return dto.BidResponseDTO{}, repository.ErrNoPermission
+
+	if err = snapshotBid(ctx, tx, bidID); err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	_, err = s.db.Exec(ctx, `
+	updatedBid, err := scanBid(tx.QueryRow(ctx, `
 		UPDATE bids
 		SET name = COALESCE(NULLIF($1, ''), name),
 			description = COALESCE(NULLIF($2, ''), description),
-			version = version + 1
-		WHERE id = $3
-	`, updates.Name, updates.Description, bidID)
+			version = version + 1,
+			updated_by = $6,
+			updated_at = NOW()
+		WHERE id = $3 AND status IN ($4, $5, $7)
+		RETURNING `+bidColumns, updates.Name, updates.Description, bidID, models.BidStatusCreated, models.BidStatusPublished, username,
+		models.BidStatusWithdrawn))
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrIllegalBidTransition)
+		}
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	var updatedBid dto.BidResponseDTO
-	err = s.db.QueryRow(ctx, `SELECT id, name, status, author_type, author_id, version, created_at FROM bids WHERE id = $1`, bidID).Scan(
-		&updatedBid.ID,
-		&updatedBid.Name,
-		&updatedBid.Status,
-		&updatedBid.AuthorType,
-		&updatedBid.AuthorID,
-		&updatedBid.Version,
-		&updatedBid.CreatedAt,
-	)
-	if err != nil {
+	if err = tx.Commit(ctx); err != nil {
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return updatedBid, nil
 }
 
+// SubmitDecision records the decision of a responsible, who decides once per bid: a
+// second decision is rejected instead of overwriting the first. Once the quorum
+// approves, the bid is awarded its open lots and the tender is closed when no lot is
+// left open.
 func (s *Storage) SubmitDecision(ctx context.Context, bidID uuid.UUID, decision, username string) (dto.BidResponseDTO, error) {
 	const op = "repository.postgres.SubmitDecision"
 
-	var exists bool
-	err := s.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM bids WHERE id = $1)`, bidID).Scan(&exists)
+	tx, err := s.begin(ctx)
 	if err != nil {
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
-	if !exists {
-		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrBidNotFound)
+	defer tx.Rollback(ctx)
+
+	var tenderID, organizationID uuid.UUID
+	var bidStatus, tenderStatus string
+	err = tx.QueryRow(ctx, `SELECT b.tender_id, t.organization_id, b.status, t.status
+		FROM bids b
+		JOIN tenders t ON t.id = b.tender_id
+		WHERE b.id = $1
+		FOR UPDATE OF b`, bidID).Scan(&tenderID, &organizationID, &bidStatus, &tenderStatus)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrBidNotFound)
+		}
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if bidStatus != models.BidStatusPublished.String() || tenderStatus != models.TenderStatusPublished.String() {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrIllegalBidTransition)
 	}
 
-	var userIsResponsible bool
-	err = s.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM organization_responsible WHERE user_id = (SELECT id FROM employee WHERE username = $1))`, username).Scan(&userIsResponsible)
+	var userID uuid.UUID
+	err = tx.QueryRow(ctx, `SELECT e.id
+		FROM employee e
+		JOIN organization_responsible r ON r.user_id = e.id
+		WHERE e.username = $1 AND r.organization_id = $2`, username, organizationID).Scan(&userID)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrNoPermission)
+		}
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
-	if !userIsResponsible {
-		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrNoPermission)
+
+	if err = checkBidLotsOpen(ctx, tx, tenderID, bidID); err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	_, err = s.db.Exec(ctx, `UPDATE bids SET status = $1 WHERE id = $2`, decision, bidID)
+	_, err = tx.Exec(ctx, `INSERT INTO bid_decisions (bid_id, user_id, decision, created_at) VALUES ($1, $2, $3, NOW())`,
+		bidID, userID, decision)
 	if err != nil {
+		if isPgError(err, uniqueViolation) {
+			return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrDecisionAlreadySubmitted)
+		}
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if decision == DecisionApproved {
-		tenderID := s.getTenderIDForBid(ctx, bidID)
-		if err = s.closeTender(ctx, tenderID); err != nil {
+	tally, err := tallyDecisions(ctx, tx, bidID, organizationID)
+	if err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	switch {
+	case tally.Rejections > 0:
+		if err = decideBid(ctx, tx, bidID, models.BidStatusRejected, username); err != nil {
+			return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+		}
+	case tally.Approvals >= tally.Quorum:
+		if err = decideBid(ctx, tx, bidID, models.BidStatusApproved, username); err != nil {
+			return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+		}
+		if err = awardBidLots(ctx, tx, bidID); err != nil {
 			return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 		}
+		if err = closeSettledTender(ctx, tx, tenderID, username); err != nil {
+			return dto.BidResponseDTO{}, fmt.Errorf("%s: %w: %w", op, repository.ErrTenderCloseFailed, err)
+		}
 	}
 
-	var bid dto.BidResponseDTO
-	query := `SELECT id, name, status, author_type, author_id, version, created_at FROM bids WHERE id = $1`
-	err = s.db.QueryRow(ctx, query, bidID).Scan(
-		&bid.ID,
-		&bid.Name,
-		&bid.Status,
-		&bid.AuthorType,
-		&bid.AuthorID,
-		&bid.Version,
-		&bid.CreatedAt,
-	)
+	bid, err := scanBid(tx.QueryRow(ctx, `SELECT `+bidColumns+` FROM bids WHERE id = $1`, bidID))
 	if err != nil {
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
+	if err = tx.Commit(ctx); err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	return bid, nil
 }
 
-func (s *Storage) SendFeedback(ctx context.Context, bidID uuid.UUID, feedback, username string) (dto.BidResponseDTO, error) {
-	const op = "repository.postgres.SubmitFeedback"
+func (s *Storage) GetBidDecisions(ctx context.Context, bidID uuid.UUID) (dto.BidDecisionsDTO, error) {
+	const op = "repository.postgres.GetBidDecisions"
 
-	var exists bool
-	err := s.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM bids WHERE id = $1)`, bidID).Scan(&exists)
+	var organizationID uuid.UUID
+	err := s.conn(ctx).QueryRow(ctx, `SELECT t.organization_id
+		FROM bids b
+		JOIN tenders t ON t.id = b.tender_id
+		WHERE b.id = $1`, bidID).Scan(&organizationID)
 	if err != nil {
-		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
-	}
-	if !exists {
-		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrBidNotFound)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.BidDecisionsDTO{}, fmt.Errorf("%s: %w", op, repository.ErrBidNotFound)
+		}
+		return dto.BidDecisionsDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	var userIsAuthorized bool
-	err = s.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM organization_responsible WHERE user_id = (SELECT id FROM employee WHERE username = $1))`, username).Scan(&userIsAuthorized)
-	if err != nil || !userIsAuthorized {
-		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrNoPermission)
+	tally, err := tallyDecisions(ctx, s.conn(ctx), bidID, organizationID)
+	if err != nil {
+		return dto.BidDecisionsDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	_, err = s.db.Exec(ctx, `INSERT INTO bid_feedback (bid_id, feedback, author_id) VALUES ($1, $2, (SELECT id FROM employee WHERE username = $3))`, bidID, feedback, username)
+	return tally, nil
+}
+
+// SendFeedback records a review of the bid written by the reviewer on behalf of the
+// organization that owns the tender.
+func (s *Storage) SendFeedback(ctx context.Context, bidID uuid.UUID, feedback string, reviewerID, organizationID uuid.UUID) (dto.BidResponseDTO, error) {
+	const op = "repository.postgres.SendFeedback"
+
+	_, err := s.conn(ctx).Exec(ctx, `INSERT INTO bid_reviews (bid_id, reviewer_id, organization_id, description) VALUES ($1, $2, $3, $4)`,
+		bidID, reviewerID, organizationID, feedback)
 	if err != nil {
+		if isPgError(err, foreignKeyViolation) {
+			return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrBidNotFound)
+		}
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	var bid dto.BidResponseDTO
-	query := `SELECT id, name, status, author_type, author_id, version, created_at FROM bids WHERE id = $1`
-	err = s.db.QueryRow(ctx, query, bidID).Scan(
-		&bid.ID,
-		&bid.Name,
-		&bid.Status,
-		&bid.AuthorType,
-		&bid.AuthorID,
-		&bid.Version,
-		&bid.CreatedAt,
-	)
+	bid, err := scanBid(s.conn(ctx).QueryRow(ctx, `SELECT `+bidColumns+` FROM bids WHERE id = $1`, bidID))
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrBidNotFound)
+		}
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return bid, nil
 }
 
+// RollbackBidVersion restores the name, description and commercial terms of an
+// earlier version as a new version. The price of a bid in an auction only changes
+// through auction bids and is kept, and lots are only restored while they are open.
 func (s *Storage) RollbackBidVersion(ctx context.Context, bidID uuid.UUID, version int, username string) (dto.BidResponseDTO, error) {
 	const op = "repository.postgres.RollbackBidVersion"
 
-	var exists bool
-	err := s.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM bids WHERE id = $1)`, bidID).Scan(&exists)
+	tx, err := s.begin(ctx)
 	if err != nil {
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
-	if !exists {
-		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrBidNotFound)
-	}
-
-	var versionExists bool
-	err = s.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM bid_versions WHERE bid_id = $1 AND version = $2)`, bidID, version).Scan(&versionExists)
-	if err != nil || !versionExists {
-		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrVersionNotFound)
-	}
+	defer tx.Rollback(ctx)
 
-	var userIsAuthorized bool
-	err = s.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM organization_responsible WHERE user_id = (SELECT id FROM employee WHERE username = $1))`, username).Scan(&userIsAuthorized)
-	if err != nil || !userIsAuthorized {
-		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrNoPermission)
+	if err = lockBid(ctx, tx, bidID); err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	var bid dto.UpdateBidDTO
-	err = s.db.QueryRow(ctx, `SELECT name, description FROM bid_versions WHERE bid_id = $1 AND version = $2`, bidID, version).Scan(
-		&bid.Name,
-		&bid.Description,
+	var (
+		bid           dto.UpdateBidDTO
+		price         *string
+		currency      string
+		items         []dto.BidItemDTO
+		lotIDs        []uuid.UUID
+		termsRecorded bool
+		tenderID      uuid.UUID
+		inAuction     bool
+		lotsChanged   bool
+	)
+	err = tx.QueryRow(ctx, `SELECT v.name, COALESCE(v.description, ''), v.price::text, COALESCE(v.currency, ''),
+			COALESCE(v.items, '[]'), COALESCE(v.lot_ids, '{}'), v.items IS NOT NULL, b.tender_id,
+			EXISTS(SELECT 1 FROM tender_auctions a WHERE a.tender_id = b.tender_id),
+			ARRAY(SELECT l.lot_id FROM bid_lots l WHERE l.bid_id = b.id ORDER BY l.lot_id) <> COALESCE(v.lot_ids, '{}')
+		FROM bids_versions v JOIN bids b ON b.id = v.bid_id
+		WHERE v.bid_id = $1 AND v.version = $2`, bidID, version).Scan(
+		&bid.Name, &bid.Description, &price, &currency,
+		&items, &lotIDs, &termsRecorded, &tenderID,
+		&inAuction, &lotsChanged,
 	)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrVersionNotFound)
+		}
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	_, err = s.db.Exec(ctx, `UPDATE bids SET name = $1, description = $2, version = version + 1 WHERE id = $3`,
-		bid.Name, bid.Description, bidID)
-	if err != nil {
+	if err = snapshotBid(ctx, tx, bidID); err != nil {
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	var bidVersion dto.BidResponseDTO
-	err = s.db.QueryRow(ctx, `SELECT id, name, status, author_type, author_id, version, created_at FROM bids WHERE id = $1`, bidID).Scan(
-		&bidVersion.ID,
-		&bidVersion.Name,
-		&bidVersion.Status,
-		&bidVersion.AuthorType,
-		&bidVersion.AuthorID,
-		&bidVersion.Version,
-		&bidVersion.CreatedAt,
-	)
+	if termsRecorded {
+		if _, err = tx.Exec(ctx, `DELETE FROM bid_items WHERE bid_id = $1`, bidID); err != nil {
+			return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+		}
+		for i, item := range items {
+			_, err = tx.Exec(ctx, `INSERT INTO bid_items (bid_id, position, name, quantity, unit_price)
+				VALUES ($1, $2, $3, $4::text::numeric, $5::text::numeric)`, bidID, i+1, item.Name, item.Quantity, item.UnitPrice)
+			if err != nil {
+				return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		if lotsChanged {
+			if _, err = tx.Exec(ctx, `DELETE FROM bid_lots WHERE bid_id = $1`, bidID); err != nil {
+				return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+			}
+			if err = attachBidLots(ctx, tx, tenderID, bidID, lotIDs); err != nil {
+				return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+
+	bidVersion, err := scanBid(tx.QueryRow(ctx, `UPDATE bids SET name = $1, description = $2,
+			price = CASE WHEN $7 THEN $8::text::numeric ELSE price END,
+			currency = CASE WHEN $7 THEN NULLIF($9, '') ELSE currency END,
+			version = version + 1, updated_by = $6, updated_at = NOW()
+		WHERE id = $3 AND status NOT IN ($4, $5)
+		RETURNING `+bidColumns, bid.Name, bid.Description, bidID, models.BidStatusApproved, models.BidStatusRejected, username,
+		termsRecorded && !inAuction, price, currency))
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrBidAlreadyDecided)
+		}
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return bidVersion, nil
 }
 
-func (s *Storage) GetBidReviews(ctx context.Context, tenderID uuid.UUID, authorUsername, requesterUsername string, limit, offset int) ([]dto.BidReviewDTO, error) {
+// GetBidReviews returns the reviews left by the organization that owns the tender on
+// any bid of the author, who must have bid on this tender. Bids count as the author's
+// when submitted by the employee or by an organization the employee is responsible for.
+func (s *Storage) GetBidReviews(ctx context.Context, tenderID uuid.UUID, authorUsername string, q dto.ListQueryDTO) (dto.PageDTO[dto.BidReviewDTO], error) {
 	const op = "repository.postgres.GetBidReviews"
 
-	var exists bool
-	err := s.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM tenders WHERE id = $1)`, tenderID).Scan(&exists)
+	var organizationID uuid.UUID
+	err := s.conn(ctx).QueryRow(ctx, `SELECT organization_id FROM tenders WHERE id = $1`, tenderID).Scan(&organizationID)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.PageDTO[dto.BidReviewDTO]{}, fmt.Errorf("%s: %w", op, repository.ErrTenderNotFound)
+		}
+		return dto.PageDTO[dto.BidReviewDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var authorID uuid.UUID
+	err = s.conn(ctx).QueryRow(ctx, `SELECT id FROM employee WHERE username = $1`, authorUsername).Scan(&authorID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.PageDTO[dto.BidReviewDTO]{}, fmt.Errorf("%s: %w", op, repository.ErrUserNotFound)
+		}
+		return dto.PageDTO[dto.BidReviewDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// authorBids matches the bids of the author; %[1]s is the placeholder of the author id.
+	const authorBids = `(b.author_type = 'User' AND b.author_id = %[1]s)
+		OR (b.author_type = 'Organization' AND b.author_id IN (SELECT organization_id FROM organization_responsible WHERE user_id = %[1]s))`
+
+	var hasBid bool
+	err = s.conn(ctx).QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM bids b WHERE b.tender_id = $2 AND (`+fmt.Sprintf(authorBids, "$1")+`))`,
+		authorID, tenderID).Scan(&hasBid)
+	if err != nil {
+		return dto.PageDTO[dto.BidReviewDTO]{}, fmt.Errorf("%s: %w", op, err)
 	}
-	if !exists {
-		return nil, fmt.Errorf("%s: %w", op, repository.ErrTenderNotFound)
+	if !hasBid {
+		return dto.PageDTO[dto.BidReviewDTO]{}, fmt.Errorf("%s: %w", op, repository.ErrBidNotFound)
 	}
 
-	var userIsResponsible bool
-	err = s.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM organization_responsible WHERE user_id = (SELECT id FROM employee WHERE username = $1))`, requesterUsername).Scan(&userIsResponsible)
-	if err != nil || !userIsResponsible {
-		return nil, fmt.Errorf("%s: %w", op, repository.ErrNoPermission)
+	var b listBuilder
+	b.where("r.organization_id = %s", organizationID)
+	b.where("("+authorBids+")", authorID)
+
+	order, err := reviewListSpec.apply(&b, q)
+	if err != nil {
+		return dto.PageDTO[dto.BidReviewDTO]{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	rows, err := s.db.Query(ctx, `
-		SELECT r.id, r.description, r.created_at 
-		FROM bid_reviews r
-		JOIN bids b ON r.bid_id = b.id
-		JOIN employees e ON b.author_id = e.id
-		WHERE b.tender_id = $1 AND e.username = $2
-		LIMIT $3 OFFSET $4`, tenderID, authorUsername, limit, offset)
+	const from = `bid_reviews r JOIN bids b ON b.id = r.bid_id`
+
+	total, err := s.countRows(ctx, from, &b)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return dto.PageDTO[dto.BidReviewDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if total == 0 {
+		return dto.PageDTO[dto.BidReviewDTO]{}, fmt.Errorf("%s: %w", op, repository.ErrReviewsNotFound)
+	}
+	if err = reviewListSpec.seek(&b, order, q.After); err != nil {
+		return dto.PageDTO[dto.BidReviewDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.conn(ctx).Query(ctx, `SELECT r.id, COALESCE(r.description, ''), r.bid_id, b.tender_id, r.organization_id,
+			COALESCE(e.username, ''), r.created_at
+		FROM `+from+`
+		LEFT JOIN employee e ON e.id = r.reviewer_id`+b.whereClause()+`
+		ORDER BY `+order.sql+`
+		`+b.page(q), b.args...)
+	if err != nil {
+		return dto.PageDTO[dto.BidReviewDTO]{}, fmt.Errorf("%s: %w", op, err)
 	}
 	defer rows.Close()
 
-	var reviews []dto.BidReviewDTO
+	reviews := []dto.BidReviewDTO{}
 	for rows.Next() {
 		var review dto.BidReviewDTO
-		err = rows.Scan(&review.ID, &review.Description, &review.CreatedAt)
+		err = rows.Scan(&review.ID, &review.Description, &review.BidID, &review.TenderID, &review.OrganizationID,
+			&review.ReviewerUsername, &review.CreatedAt)
 		if err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+			return dto.PageDTO[dto.BidReviewDTO]{}, fmt.Errorf("%s: %w", op, err)
 		}
 		reviews = append(reviews, review)
 	}
-
-	if len(reviews) == 0 {
-		return nil, fmt.Errorf("%s: %w", op, repository.ErrReviewsNotFound)
+	if err = rows.Err(); err != nil {
+		return dto.PageDTO[dto.BidReviewDTO]{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return reviews, nil
+	return dto.PageDTO[dto.BidReviewDTO]{
+		Items: reviews,
+		Total: total,
+		NextCursor: nextCursor(order, q.Limit, len(reviews), func() dto.Cursor {
+			last := reviews[len(reviews)-1]
+			return dto.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+		}),
+	}, nil
 }
 
 func (s *Storage) Close() {
@@ -650,31 +1140,92 @@ func (s *Storage) Close() {
 	return
 }
 
-func (s *Storage) isUserResponsibleForAnyOrganization(ctx context.Context, username string) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM organization_responsibles WHERE username = $1)`
-	var isResponsible bool
-	err := s.db.QueryRow(ctx, query, username).Scan(&isResponsible)
-	return isResponsible, err
+// tallyDecisions counts the recorded decisions on a bid against the quorum of
+// the organization that owns the tender: min(3, number of responsibles).
+func tallyDecisions(ctx context.Context, q querier, bidID, organizationID uuid.UUID) (dto.BidDecisionsDTO, error) {
+	tally := dto.BidDecisionsDTO{BidID: bidID, Decisions: []dto.BidDecisionDTO{}}
+
+	err := q.QueryRow(ctx, `SELECT status FROM bids WHERE id = $1`, bidID).Scan(&tally.Status)
+	if err != nil {
+		return dto.BidDecisionsDTO{}, err
+	}
+
+	err = q.QueryRow(ctx, `SELECT LEAST(3, COUNT(*)) FROM organization_responsible WHERE organization_id = $1`, organizationID).Scan(&tally.Quorum)
+	if err != nil {
+		return dto.BidDecisionsDTO{}, err
+	}
+
+	rows, err := q.Query(ctx, `SELECT e.username, d.decision, d.created_at
+		FROM bid_decisions d
+		JOIN employee e ON e.id = d.user_id
+		WHERE d.bid_id = $1 AND d.invalidated_at IS NULL
+		ORDER BY d.created_at ASC`, bidID)
+	if err != nil {
+		return dto.BidDecisionsDTO{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var decision dto.BidDecisionDTO
+		if err = rows.Scan(&decision.Username, &decision.Decision, &decision.CreatedAt); err != nil {
+			return dto.BidDecisionsDTO{}, err
+		}
+		switch decision.Decision {
+		case models.BidStatusApproved.String():
+			tally.Approvals++
+		case models.BidStatusRejected.String():
+			tally.Rejections++
+		}
+		tally.Decisions = append(tally.Decisions, decision)
+	}
+	if err = rows.Err(); err != nil {
+		return dto.BidDecisionsDTO{}, err
+	}
+
+	return tally, nil
 }
 
-func (s *Storage) IsUserResponsibleForOrganization(ctx context.Context, username, organizationID string) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM organization_responsibles WHERE username = $1 AND organization_id = $2)`
-	var isResponsible bool
-	err := s.db.QueryRow(ctx, query, username, organizationID).Scan(&isResponsible)
-	return isResponsible, err
+// decideBid moves the bid to the decided status as a new version on behalf of actor.
+func decideBid(ctx context.Context, q querier, bidID uuid.UUID, status models.BidStatus, actor string) error {
+	if err := snapshotBid(ctx, q, bidID); err != nil {
+		return err
+	}
+
+	_, err := q.Exec(ctx, `UPDATE bids SET status = $1, version = version + 1, updated_by = $2, updated_at = NOW() WHERE id = $3`,
+		status, actor, bidID)
+	return err
 }
 
-func (s *Storage) getTenderIDForBid(ctx context.Context, bidID uuid.UUID) uuid.UUID {
-	// Реализация получения ID тендера по предложению
-	var tenderID uuid.UUID
-	err := s.db.QueryRow(ctx, `SELECT tender_id FROM bids WHERE id = $1`, bidID).Scan(&tenderID)
+// closeTender closes a published tender as a new version on behalf of actor and
+// records the transition. A tender that is not published is left untouched.
+func closeTender(ctx context.Context, q querier, tenderID uuid.UUID, actor string) error {
+	var status models.TenderStatus
+	err := q.QueryRow(ctx, `SELECT status FROM tenders WHERE id = $1 FOR UPDATE`, tenderID).Scan(&status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.ErrTenderNotFound
+		}
+		return err
+	}
+	if status != models.TenderStatusPublished {
+		return nil
+	}
+
+	if err = snapshotTender(ctx, q, tenderID); err != nil {
+		return err
+	}
+
+	_, err = q.Exec(ctx, `UPDATE tenders SET status = $1, version = version + 1, updated_by = $2, updated_at = NOW() WHERE id = $3`,
+		models.TenderStatusClosed, actor, tenderID)
 	if err != nil {
-		return uuid.Nil
+		return err
 	}
-	return tenderID
+
+	return recordTenderTransition(ctx, q, tenderID, models.TenderStatusPublished, models.TenderStatusClosed, actor)
 }
 
-func (s *Storage) closeTender(ctx context.Context, tenderID uuid.UUID) error {
-	_, err := s.db.Exec(ctx, `UPDATE tenders SET status = 'CLOSED' WHERE id = $1`, tenderID)
+func recordTenderTransition(ctx context.Context, q querier, tenderID uuid.UUID, from, to models.TenderStatus, actor string) error {
+	_, err := q.Exec(ctx, `INSERT INTO tender_status_history (tender_id, from_status, to_status, actor_username, created_at)
+		VALUES ($1, NULLIF($2, ''), $3, $4, NOW())`, tenderID, from, to, actor)
 	return err
 }
@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const questionColumns = `q.id, q.tender_id, a.username, q.question, COALESCE(q.answer, ''), COALESCE(r.username, ''),
+	q.answer_public, q.created_at, q.answered_at`
+
+const questionJoins = ` JOIN employee a ON a.id = q.author_id LEFT JOIN employee r ON r.id = q.answered_by`
+
+func scanQuestion(row pgx.Row) (dto.TenderQuestionDTO, error) {
+	var question dto.TenderQuestionDTO
+	err := row.Scan(&question.ID, &question.TenderID, &question.AuthorUsername, &question.Question, &question.Answer,
+		&question.AnsweredBy, &question.Public, &question.CreatedAt, &question.AnsweredAt)
+	return question, err
+}
+
+func (s *Storage) CreateTenderQuestion(ctx context.Context, tenderID, authorID uuid.UUID, question string) (dto.TenderQuestionDTO, error) {
+	const op = "storage.postgres.CreateTenderQuestion"
+
+	created, err := scanQuestion(s.conn(ctx).QueryRow(ctx, `WITH q AS (
+			INSERT INTO tender_questions (tender_id, author_id, question, created_at) VALUES ($1, $2, $3, NOW()) RETURNING *
+		)
+		SELECT `+questionColumns+` FROM q`+questionJoins, tenderID, authorID, question))
+	if err != nil {
+		return dto.TenderQuestionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return created, nil
+}
+
+// AnswerTenderQuestion sets or replaces the answer to a question of the tender.
+func (s *Storage) AnswerTenderQuestion(ctx context.Context, tenderID, questionID, answeredBy uuid.UUID, answer dto.AnswerDTO) (dto.TenderQuestionDTO, error) {
+	const op = "storage.postgres.AnswerTenderQuestion"
+
+	answered, err := scanQuestion(s.conn(ctx).QueryRow(ctx, `WITH q AS (
+			UPDATE tender_questions SET answer = $3, answer_public = $4, answered_by = $5, answered_at = NOW()
+			WHERE id = $1 AND tender_id = $2 RETURNING *
+		)
+		SELECT `+questionColumns+` FROM q`+questionJoins, questionID, tenderID, answer.Answer, answer.Public, answeredBy))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.TenderQuestionDTO{}, fmt.Errorf("%s: %w", op, repository.ErrQuestionNotFound)
+		}
+		return dto.TenderQuestionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return answered, nil
+}
+
+// GetTenderQuestions lists the questions of a tender. Unless all is set, only
+// questions with a public answer and the viewer's own questions are listed.
+func (s *Storage) GetTenderQuestions(ctx context.Context, tenderID, viewerID uuid.UUID, all bool, q dto.ListQueryDTO) (dto.PageDTO[dto.TenderQuestionDTO], error) {
+	const op = "storage.postgres.GetTenderQuestions"
+
+	var b listBuilder
+	b.where("q.tender_id = %s", tenderID)
+	if !all {
+		b.where("(q.answer_public OR q.author_id = %s)", viewerID)
+	}
+
+	order, err := questionListSpec.apply(&b, q)
+	if err != nil {
+		return dto.PageDTO[dto.TenderQuestionDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	total, err := s.countRows(ctx, "tender_questions q", &b)
+	if err != nil {
+		return dto.PageDTO[dto.TenderQuestionDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = questionListSpec.seek(&b, order, q.After); err != nil {
+		return dto.PageDTO[dto.TenderQuestionDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.conn(ctx).Query(ctx, `SELECT `+questionColumns+`
+		FROM tender_questions q`+questionJoins+b.whereClause()+`
+		ORDER BY `+order.sql+`
+		`+b.page(q), b.args...)
+	if err != nil {
+		return dto.PageDTO[dto.TenderQuestionDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	questions := []dto.TenderQuestionDTO{}
+	for rows.Next() {
+		question, err := scanQuestion(rows)
+		if err != nil {
+			return dto.PageDTO[dto.TenderQuestionDTO]{}, fmt.Errorf("%s: %w", op, err)
+		}
+		questions = append(questions, question)
+	}
+	if err = rows.Err(); err != nil {
+		return dto.PageDTO[dto.TenderQuestionDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dto.PageDTO[dto.TenderQuestionDTO]{
+		Items: questions,
+		Total: total,
+		NextCursor: nextCursor(order, q.Limit, len(questions), func() dto.Cursor {
+			last := questions[len(questions)-1]
+			return dto.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+		}),
+	}, nil
+}
@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"git.codenrock.com/avito/internal/domain/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+// CloseExpiredTenders closes up to limit published tenders whose decision deadline
+// has passed and records each transition on behalf of actor. A tender without a
+// decision deadline is due decisionPeriod after its submission deadline.
+func (s *Storage) CloseExpiredTenders(ctx context.Context, actor string, decisionPeriod time.Duration, limit int) ([]uuid.UUID, error) {
+	const op = "storage.postgres.CloseExpiredTenders"
+
+	ids, err := s.transitionDueTenders(ctx, "COALESCE(decision_deadline, submission_deadline + $3 * INTERVAL '1 second')",
+		models.TenderStatusPublished, models.TenderStatusClosed, actor, limit, int64(decisionPeriod/time.Second))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ids, nil
+}
+
+// PublishScheduledTenders publishes up to limit created tenders whose publish_at has
+// come and records each transition on behalf of actor.
+func (s *Storage) PublishScheduledTenders(ctx context.Context, actor string, limit int) ([]uuid.UUID, error) {
+	const op = "storage.postgres.PublishScheduledTenders"
+
+	ids, err := s.transitionDueTenders(ctx, "publish_at", models.TenderStatusCreated, models.TenderStatusPublished, actor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ids, nil
+}
+
+// transitionDueTenders moves tenders in the from status whose due time has passed to
+// the to status, snapshotting and logging each change like a manual status update.
+// due is an SQL expression over the tender columns; its own arguments start at $3.
+// Tenders locked by a concurrent transaction are skipped and picked up by the next run.
+func (s *Storage) transitionDueTenders(ctx context.Context, due string, from, to models.TenderStatus, actor string, limit int, dueArgs ...any) ([]uuid.UUID, error) {
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `SELECT id FROM tenders
+		WHERE status = $1 AND `+due+` <= NOW()
+		ORDER BY `+due+`
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED`, append([]any{from, limit}, dueArgs...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err = rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		if err = snapshotTender(ctx, tx, id); err != nil {
+			return nil, err
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE tenders SET status = $1, version = version + 1, updated_by = $2, updated_at = NOW() WHERE id = $3`,
+			to, actor, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = recordTenderTransition(ctx, tx, id, from, to, actor); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/jackc/pgx/v5"
+)
+
+type txKey struct{}
+
+// txAttempts bounds how many times a transaction chosen as a deadlock victim is run.
+const txAttempts = 3
+
+// WithinTx runs fn in a transaction. Storage methods called with the context passed
+// to fn take part in that transaction. A nested WithinTx runs in a savepoint of the
+// outer transaction. An outer transaction aborted by a deadlock is run again from the
+// start; when it keeps deadlocking, ErrConcurrentUpdate is returned.
+func (s *Storage) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	const op = "storage.postgres.WithinTx"
+
+	if _, nested := ctx.Value(txKey{}).(pgx.Tx); nested {
+		return s.runTx(ctx, fn)
+	}
+
+	var err error
+	for attempt := 0; attempt < txAttempts; attempt++ {
+		if err = s.runTx(ctx, fn); !isPgError(err, deadlockDetected) {
+			return err
+		}
+	}
+
+	return fmt.Errorf("%s: %w: %w", op, repository.ErrConcurrentUpdate, err)
+}
+
+func (s *Storage) runTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	const op = "storage.postgres.WithinTx"
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// begin starts a transaction, or a savepoint when ctx already carries one.
+func (s *Storage) begin(ctx context.Context) (pgx.Tx, error) {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx.Begin(ctx)
+	}
+	return s.db.Begin(ctx)
+}
+
+// conn returns the transaction carried by ctx or the pool outside of a transaction.
+func (s *Storage) conn(ctx context.Context) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return s.db
+}
@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const tenderColumns = `id, name, COALESCE(description, ''), status, COALESCE(service_type, ''), organization_id,
+	COALESCE(creator_username, ''), version, created_at, updated_at, submission_deadline, decision_deadline, publish_at,
+	budget_min::text, budget_max::text, COALESCE(currency, ''), vat_included, cap_at_budget, visibility, sealed,
+	ARRAY(SELECT i.organization_id FROM tender_invitations i WHERE i.tender_id = tenders.id ORDER BY i.organization_id)`
+
+// bidColumns selects a bid from the bids table together with its line items as a
+// JSON array and the ids of its lots. Amounts are read as text so no precision is lost.
+const bidColumns = `id, name, status, COALESCE(author_type, ''), author_id, version, created_at, updated_at,
+	COALESCE(withdrawal_reason, ''), withdrawn_at, price::text, COALESCE(currency, ''),
+	COALESCE((SELECT json_agg(json_build_object('name', i.name, 'quantity', i.quantity::text, 'unit_price', i.unit_price::text,
+			'amount', ROUND(i.quantity * i.unit_price, 2)::text) ORDER BY i.position)
+		FROM bid_items i WHERE i.bid_id = bids.id), '[]'),
+	ARRAY(SELECT l.lot_id FROM bid_lots l WHERE l.bid_id = bids.id ORDER BY l.lot_id)`
+
+// bidItemsJSON selects the line items of a bid from the bids table as the JSON array
+// stored in bids_versions, and bidLotIDs the ids of its lots.
+const (
+	bidItemsJSON = `(SELECT COALESCE(jsonb_agg(jsonb_build_object('name', i.name, 'quantity', i.quantity::text,
+			'unit_price', i.unit_price::text) ORDER BY i.position), '[]')
+		FROM bid_items i WHERE i.bid_id = bids.id)`
+	bidLotIDs = `ARRAY(SELECT l.lot_id FROM bid_lots l WHERE l.bid_id = bids.id ORDER BY l.lot_id)`
+)
+
+func scanTender(row pgx.Row) (dto.TenderResponseDTO, error) {
+	var tender dto.TenderResponseDTO
+	err := row.Scan(&tender.ID, &tender.Name, &tender.Description, &tender.Status, &tender.ServiceType,
+		&tender.OrganizationID, &tender.CreatorUsername, &tender.Version, &tender.CreatedAt, &tender.UpdatedAt,
+		&tender.SubmissionDeadline, &tender.DecisionDeadline, &tender.PublishAt,
+		&tender.BudgetMin, &tender.BudgetMax, &tender.Currency, &tender.VATIncluded, &tender.CapAtBudget,
+		&tender.Visibility, &tender.Sealed, &tender.InvitedOrganizations)
+	return tender, err
+}
+
+func scanBid(row pgx.Row) (dto.BidResponseDTO, error) {
+	var bid dto.BidResponseDTO
+	err := row.Scan(&bid.ID, &bid.Name, &bid.Status, &bid.AuthorType, &bid.AuthorID, &bid.Version, &bid.CreatedAt, &bid.UpdatedAt,
+		&bid.WithdrawalReason, &bid.WithdrawnAt, &bid.Price, &bid.Currency, &bid.Items, &bid.LotIDs)
+	return bid, err
+}
+
+// lockTender locks the tender row until the end of the transaction.
+func lockTender(ctx context.Context, q querier, tenderID uuid.UUID) error {
+	var id uuid.UUID
+	err := q.QueryRow(ctx, `SELECT id FROM tenders WHERE id = $1 FOR UPDATE`, tenderID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.ErrTenderNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// snapshotTender copies the current state of a tender into tender_versions. It must
+// run after lockTender, in the same transaction as the update that bumps the tender
+// version, so a second snapshot of the same version fails instead of being dropped.
+func snapshotTender(ctx context.Context, q querier, tenderID uuid.UUID) error {
+	_, err := q.Exec(ctx, `INSERT INTO tender_versions (tender_id, name, description, service_type, status, version, edited_by, created_at, updated_at)
+		SELECT id, name, description, service_type, status, version, updated_by, updated_at, NOW()
+		FROM tenders WHERE id = $1`, tenderID)
+	return err
+}
+
+// snapshotBid copies the current state of a bid into bids_versions. It must run after
+// lockBid, in the same transaction as the update that bumps the bid version.
+func snapshotBid(ctx context.Context, q querier, bidID uuid.UUID) error {
+	_, err := q.Exec(ctx, `INSERT INTO bids_versions (bid_id, version, name, description, status, author_type, author_id, withdrawal_reason,
+			edited_by, created_at, updated_at, price, currency, items, lot_ids)
+		SELECT id, version, name, description, status, author_type, author_id, withdrawal_reason, updated_by, updated_at, NOW(),
+			price, currency, `+bidItemsJSON+`, `+bidLotIDs+`
+		FROM bids WHERE id = $1`, bidID)
+	return err
+}
+
+// lockBid locks the bid row until the end of the transaction.
+func lockBid(ctx context.Context, q querier, bidID uuid.UUID) error {
+	var id uuid.UUID
+	err := q.QueryRow(ctx, `SELECT id FROM bids WHERE id = $1 FOR UPDATE`, bidID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.ErrBidNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *Storage) GetTenderVersions(ctx context.Context, tenderID uuid.UUID) ([]dto.VersionDTO, error) {
+	const op = "storage.postgres.GetTenderVersions"
+
+	rows, err := s.conn(ctx).Query(ctx, `
+		SELECT version, name, COALESCE(description, ''), COALESCE(service_type, ''), status, COALESCE(edited_by, ''), created_at,
+			NULL::text, '', NULL::jsonb, NULL::uuid[]
+		FROM tender_versions WHERE tender_id = $1
+		UNION ALL
+		SELECT version, name, COALESCE(description, ''), COALESCE(service_type, ''), status, COALESCE(updated_by, ''), updated_at,
+			NULL::text, '', NULL::jsonb, NULL::uuid[]
+		FROM tenders WHERE id = $1
+		ORDER BY version ASC`, tenderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	versions, err := scanVersions(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, repository.ErrTenderNotFound)
+	}
+
+	return versions, nil
+}
+
+func (s *Storage) GetBidVersions(ctx context.Context, bidID uuid.UUID) ([]dto.VersionDTO, error) {
+	const op = "storage.postgres.GetBidVersions"
+
+	rows, err := s.conn(ctx).Query(ctx, `
+		SELECT version, name, COALESCE(description, ''), '', status, COALESCE(edited_by, ''), created_at,
+			price::text, COALESCE(currency, ''), items, lot_ids
+		FROM bids_versions WHERE bid_id = $1
+		UNION ALL
+		SELECT version, name, COALESCE(description, ''), '', status, COALESCE(updated_by, ''), updated_at,
+			price::text, COALESCE(currency, ''), `+bidItemsJSON+`, `+bidLotIDs+`
+		FROM bids WHERE id = $1
+		ORDER BY version ASC`, bidID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	versions, err := scanVersions(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, repository.ErrBidNotFound)
+	}
+
+	return versions, nil
+}
+
+func scanVersions(rows pgx.Rows) ([]dto.VersionDTO, error) {
+	defer rows.Close()
+
+	var versions []dto.VersionDTO
+	for rows.Next() {
+		var v dto.VersionDTO
+		if err := rows.Scan(&v.Version, &v.Name, &v.Description, &v.ServiceType, &v.Status, &v.EditedBy, &v.CreatedAt,
+			&v.Price, &v.Currency, &v.Items, &v.LotIDs); err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+
+	return versions, rows.Err()
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/domain/models"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// WithdrawBid takes a published bid back from consideration and records the reason.
+// The previous state is kept in bids_versions.
+func (s *Storage) WithdrawBid(ctx context.Context, bidID uuid.UUID, reason, username string) (dto.BidResponseDTO, error) {
+	const op = "repository.postgres.WithdrawBid"
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err = lockBid(ctx, tx, bidID); err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = snapshotBid(ctx, tx, bidID); err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	bid, err := scanBid(tx.QueryRow(ctx, `UPDATE bids SET status = $1, withdrawal_reason = $2, withdrawn_at = NOW(),
+			version = version + 1, updated_by = $3, updated_at = NOW()
+		WHERE id = $4 AND status = $5
+		RETURNING `+bidColumns, models.BidStatusWithdrawn, reason, username, bidID, models.BidStatusPublished))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrIllegalBidTransition)
+		}
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return bid, nil
+}
+
+// ResubmitBid publishes a withdrawn bid again. Decisions made on the bid before it
+// was withdrawn are marked invalidated, so the revised bid goes through the quorum afresh.
+func (s *Storage) ResubmitBid(ctx context.Context, bidID uuid.UUID, username string) (dto.BidResponseDTO, error) {
+	const op = "repository.postgres.ResubmitBid"
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err = lockBid(ctx, tx, bidID); err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = snapshotBid(ctx, tx, bidID); err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	bid, err := scanBid(tx.QueryRow(ctx, `UPDATE bids SET status = $1, withdrawal_reason = NULL, withdrawn_at = NULL,
+			version = version + 1, updated_by = $2, updated_at = NOW()
+		WHERE id = $3 AND status = $4
+		RETURNING `+bidColumns, models.BidStatusPublished, username, bidID, models.BidStatusWithdrawn))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrIllegalBidTransition)
+		}
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = tx.Exec(ctx, `UPDATE bid_decisions SET invalidated_at = NOW()
+		WHERE bid_id = $1 AND invalidated_at IS NULL`, bidID); err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return bid, nil
+}
@@ -15,4 +15,58 @@ var (
 	ErrTenderCloseFailed                   = fmt.Errorf("tender close failed")
 	ErrVersionNotFound                     = fmt.Errorf("version not found")
 	ErrReviewsNotFound                     = fmt.Errorf("reviews not found")
+	ErrInvalidTenderStatus                 = fmt.Errorf("invalid tender status")
+	ErrIllegalTenderTransition             = fmt.Errorf("tender status transition is not allowed")
+	ErrInvalidBidStatus                    = fmt.Errorf("invalid bid status")
+	ErrIllegalBidTransition                = fmt.Errorf("bid status transition is not allowed")
+	ErrBidAlreadyDecided                   = fmt.Errorf("bid has already been decided")
+	ErrDecisionAlreadySubmitted            = fmt.Errorf("responsible has already decided on the bid")
+	ErrInvalidAuthorType                   = fmt.Errorf("invalid author type")
+	ErrVersionConflict                     = fmt.Errorf("entity version has changed")
+	ErrUserNotFound                        = fmt.Errorf("user not found")
+	ErrUserAlreadyExists                   = fmt.Errorf("user already exists")
+	ErrInvalidOrganizationType             = fmt.Errorf("invalid organization type")
+	ErrResponsibleAlreadyExists            = fmt.Errorf("user is already responsible for the organization")
+	ErrResponsibleNotFound                 = fmt.Errorf("user is not responsible for the organization")
+	ErrEntityInUse                         = fmt.Errorf("entity is referenced by other records")
+	ErrInvalidDeadline                     = fmt.Errorf("invalid tender deadline")
+	ErrSubmissionClosed                    = fmt.Errorf("tender submission deadline has passed")
+	ErrDecisionClosed                      = fmt.Errorf("tender decision deadline has passed")
+	ErrInvalidListQuery                    = fmt.Errorf("unsupported filter or sort field")
+	ErrInvalidAmount                       = fmt.Errorf("invalid amount")
+	ErrInvalidBudget                       = fmt.Errorf("invalid tender budget")
+	ErrInvalidCurrency                     = fmt.Errorf("invalid currency")
+	ErrCurrencyMismatch                    = fmt.Errorf("bid currency does not match the tender")
+	ErrPriceAboveBudget                    = fmt.Errorf("bid price exceeds the tender budget")
+	ErrInvalidLot                          = fmt.Errorf("invalid lot")
+	ErrLotNotFound                         = fmt.Errorf("lot not found")
+	ErrLotNotOpen                          = fmt.Errorf("lot is not open")
+	ErrLastOpenLot                         = fmt.Errorf("the last open lot of an unpublished tender cannot be cancelled")
+	ErrInvalidBidLots                      = fmt.Errorf("bid lots do not match the tender lots")
+	ErrInvalidCriteria                     = fmt.Errorf("criteria weights must be positive and add up to 100")
+	ErrCriteriaLocked                      = fmt.Errorf("criteria cannot change once bids have been scored")
+	ErrCriterionNotFound                   = fmt.Errorf("criterion not found")
+	ErrInvalidScore                        = fmt.Errorf("invalid score")
+	ErrInvalidComparison                   = fmt.Errorf("select from 1 to 20 bids to compare")
+	ErrBidNotScorable                      = fmt.Errorf("only published bids of a published tender can be scored")
+	ErrQuestionNotFound                    = fmt.Errorf("question not found")
+	ErrInvalidQuestion                     = fmt.Errorf("invalid question or answer")
+	ErrTenderNotPublished                  = fmt.Errorf("tender is not published")
+	ErrLotsLocked                          = fmt.Errorf("lots can only be added before the tender is published")
+	ErrInvalidVisibility                   = fmt.Errorf("invalid tender visibility")
+	ErrInvitationNotFound                  = fmt.Errorf("invitation not found")
+	ErrOrganizationNotInvited              = fmt.Errorf("organization is not invited to the tender")
+	ErrAmbiguousOrganization               = fmt.Errorf("employee belongs to several organizations, organization_id is required")
+	ErrInvalidWithdrawalReason             = fmt.Errorf("withdrawal reason is empty or too long")
+	ErrSealedWithoutDeadline               = fmt.Errorf("sealed tender needs a submission deadline")
+	ErrSealingLocked                       = fmt.Errorf("sealing can only change before the tender is published")
+	ErrBidsSealed                          = fmt.Errorf("bids are sealed until the submission deadline")
+	ErrAuctionNotFound                     = fmt.Errorf("auction not found")
+	ErrInvalidAuction                      = fmt.Errorf("invalid auction settings")
+	ErrAuctionLocked                       = fmt.Errorf("auction can only be configured before the tender is published")
+	ErrAuctionNotOpen                      = fmt.Errorf("auction is not accepting prices")
+	ErrAuctionRunning                      = fmt.Errorf("auction has not ended yet")
+	ErrPriceNotLowEnough                   = fmt.Errorf("price must undercut the best price by the minimum decrement")
+	ErrNotAuctionWinner                    = fmt.Errorf("only the auction winner can be approved")
+	ErrConcurrentUpdate                    = fmt.Errorf("update conflicted with a concurrent one")
 )
@@ -5,26 +5,53 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func InitRoutes(r *gin.Engine, tenderHandler *handlers.TenderHandler, bidHandler *handlers.BidHandler) {
-	api := r.Group("/api")
+func InitRoutes(r *gin.Engine, authHandler *handlers.AuthHandler, tenderHandler *handlers.TenderHandler, bidHandler *handlers.BidHandler,
+	employeeHandler *handlers.EmployeeHandler, organizationHandler *handlers.OrganizationHandler) {
+	api := r.Group("/api", authHandler.Authenticate)
 	{
 		api.GET("/ping", func(c *gin.Context) {
 			c.JSON(200, gin.H{
 				"message": "ok",
 			})
 		})
+		api.POST("/auth/token", authHandler.Login)
+
 		tenders := api.Group("/tenders")
 		{
 			tenders.GET("", tenderHandler.GetTenders)
-			tenders.POST("/new", tenderHandler.CreateTender)
-			tenders.GET("/my", tenderHandler.GetTendersByUsername)
 			tenders.GET("/:tenderId/status", tenderHandler.GetTenderStatus)
-			tenders.PUT("/:tenderId/status", tenderHandler.UpdateTenderStatus)
-			tenders.PATCH("/tenders/:tenderId/edit", tenderHandler.UpdateTenderInfo)
-			tenders.PATCH("/tenders/:tenderId/rollback/:version", tenderHandler.RollbackTenderVersion)
+			tenders.GET("/:tenderId/status/history", tenderHandler.GetTenderStatusHistory)
+			tenders.GET("/:tenderId/lots", tenderHandler.GetTenderLots)
+			tenders.GET("/:tenderId/criteria", tenderHandler.GetTenderCriteria)
+			tenders.GET("/:tenderId/questions", tenderHandler.GetTenderQuestions)
+			tenders.GET("/:tenderId/auction", tenderHandler.GetTenderAuction)
 		}
 
-		bids := api.Group("/bids")
+		privateTenders := api.Group("/tenders", authHandler.RequireAuth)
+		{
+			privateTenders.POST("/new", tenderHandler.CreateTender)
+			privateTenders.GET("/my", tenderHandler.GetTendersByUsername)
+			privateTenders.PUT("/:tenderId/status", tenderHandler.UpdateTenderStatus)
+			privateTenders.PATCH("/:tenderId/edit", tenderHandler.UpdateTenderInfo)
+			privateTenders.PATCH("/:tenderId/rollback/:version", tenderHandler.RollbackTenderVersion)
+			privateTenders.GET("/:tenderId/versions", tenderHandler.GetTenderVersions)
+			privateTenders.GET("/:tenderId/versions/:version", tenderHandler.GetTenderVersion)
+			privateTenders.GET("/:tenderId/diff", tenderHandler.GetTenderDiff)
+			privateTenders.POST("/:tenderId/lots", tenderHandler.CreateTenderLots)
+			privateTenders.PUT("/:tenderId/lots/:lotId/cancel", tenderHandler.CancelTenderLot)
+			privateTenders.PUT("/:tenderId/criteria", tenderHandler.ReplaceTenderCriteria)
+			privateTenders.GET("/:tenderId/ranking", tenderHandler.GetTenderRanking)
+			privateTenders.GET("/:tenderId/bids/compare", bidHandler.CompareTenderBids)
+			privateTenders.POST("/:tenderId/questions", tenderHandler.AskTenderQuestion)
+			privateTenders.PUT("/:tenderId/questions/:questionId/answer", tenderHandler.AnswerTenderQuestion)
+			privateTenders.GET("/:tenderId/invitations", tenderHandler.GetTenderInvitations)
+			privateTenders.POST("/:tenderId/invitations", tenderHandler.InviteOrganization)
+			privateTenders.DELETE("/:tenderId/invitations/:organizationId", tenderHandler.RevokeInvitation)
+			privateTenders.PUT("/:tenderId/auction", tenderHandler.ConfigureAuction)
+			privateTenders.POST("/:tenderId/auction/close", tenderHandler.CloseAuction)
+		}
+
+		bids := api.Group("/bids", authHandler.RequireAuth)
 		{
 			bids.POST("/new", bidHandler.CreateBid)
 			bids.GET("/my", bidHandler.GetUserBids)
@@ -33,9 +60,39 @@ func InitRoutes(r *gin.Engine, tenderHandler *handlers.TenderHandler, bidHandler
 			bids.PUT("/:id/status", bidHandler.UpdateBidStatus)
 			bids.PATCH("/:id/edit", bidHandler.UpdateBid)
 			bids.PUT("/:id/submit_decision", bidHandler.SubmitDecision)
+			bids.GET("/:id/decisions", bidHandler.GetBidDecisions)
 			bids.PUT("/:id/feedback", bidHandler.SendFeedback)
 			bids.PATCH("/:id/rollback/:version", bidHandler.RollbackBidVersion)
+			bids.GET("/:id/versions", bidHandler.GetBidVersions)
+			bids.GET("/:id/versions/:version", bidHandler.GetBidVersion)
+			bids.GET("/:id/diff", bidHandler.GetBidDiff)
 			bids.GET("/:id/reviews", bidHandler.GetBidReviews)
+			bids.GET("/:id/scores", bidHandler.GetBidScores)
+			bids.PUT("/:id/scores", bidHandler.ScoreBid)
+			bids.POST("/:id/withdraw", bidHandler.WithdrawBid)
+			bids.POST("/:id/resubmit", bidHandler.ResubmitBid)
+			bids.POST("/:id/auction_price", bidHandler.PlaceAuctionPrice)
+		}
+
+		employees := api.Group("/employees", authHandler.RequireAuth)
+		{
+			employees.POST("", employeeHandler.CreateEmployee)
+			employees.GET("", employeeHandler.GetEmployees)
+			employees.GET("/:id", employeeHandler.GetEmployee)
+			employees.PATCH("/:id", employeeHandler.UpdateEmployee)
+			employees.DELETE("/:id", employeeHandler.DeleteEmployee)
+		}
+
+		organizations := api.Group("/organizations", authHandler.RequireAuth)
+		{
+			organizations.POST("", organizationHandler.CreateOrganization)
+			organizations.GET("", organizationHandler.GetOrganizations)
+			organizations.GET("/:id", organizationHandler.GetOrganization)
+			organizations.PATCH("/:id", organizationHandler.UpdateOrganization)
+			organizations.DELETE("/:id", organizationHandler.DeleteOrganization)
+			organizations.GET("/:id/responsibles", organizationHandler.GetResponsibles)
+			organizations.POST("/:id/responsibles", organizationHandler.AddResponsible)
+			organizations.DELETE("/:id/responsibles/:userId", organizationHandler.RemoveResponsible)
 		}
 	}
 }
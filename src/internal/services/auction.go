@@ -0,0 +1,394 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"git.codenrock.com/avito/internal/auth"
+	"git.codenrock.com/avito/internal/authz"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/domain/models"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+	"log/slog"
+	"math/big"
+	"time"
+)
+
+// maxExtensionMinutes bounds the extension window and the extension itself.
+const maxExtensionMinutes = 60
+
+// GetTenderAuction returns the state of the auction to anyone who can view the tender.
+// The best price is shown without the bid that holds it.
+func (s *TenderService) GetTenderAuction(ctx context.Context, tenderID string) (dto.TenderAuctionDTO, error) {
+	const op = "services.tenderService.GetTenderAuction"
+
+	tenderUUID, err := uuid.Parse(tenderID)
+	if err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tender, err := s.db.GetTender(ctx, tenderUUID)
+	if err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = authorize(ctx, s.db, authz.TenderView, tenderResource(tender)); err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	auction, err := s.db.GetTenderAuction(ctx, tenderUUID)
+	if err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return auction, nil
+}
+
+// ConfigureAuction turns the tender into a reverse auction or changes its settings.
+// Like lots, the auction is fixed once the tender is published. The auction has to end
+// by the submission deadline, so that every bid can still take part in it.
+func (s *TenderService) ConfigureAuction(ctx context.Context, tenderID string, auction dto.AuctionDTO) (dto.TenderAuctionDTO, error) {
+	const op = "services.tenderService.ConfigureAuction"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("tenderID", tenderID),
+	)
+
+	tenderUUID, err := uuid.Parse(tenderID)
+	if err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var saved dto.TenderAuctionDTO
+	err = s.db.WithinTx(ctx, func(ctx context.Context) error {
+		tender, err := s.db.LockTender(ctx, tenderUUID)
+		if err != nil {
+			return err
+		}
+
+		if _, err = authorize(ctx, s.db, authz.TenderEdit, tenderResource(tender)); err != nil {
+			return err
+		}
+
+		if tender.Status != models.TenderStatusCreated.String() {
+			log.Warn("Cannot configure auction of a published tender", slog.String("status", tender.Status))
+			return repository.ErrAuctionLocked
+		}
+		if tender.Sealed || tender.Currency == "" {
+			log.Warn("Auction needs an unsealed tender with a currency")
+			return repository.ErrInvalidAuction
+		}
+		if err = validateAuction(&auction, tender.SubmissionDeadline, time.Now()); err != nil {
+			return err
+		}
+
+		log.Info("Configuring auction")
+
+		saved, err = s.db.SaveTenderAuction(ctx, tenderUUID, auction)
+		return err
+	})
+	if err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return saved, nil
+}
+
+// PlaceAuctionPrice lowers the price of a published bid while the auction runs. The
+// price must undercut the best price by the minimum decrement, and a price placed in
+// the last minutes extends the auction.
+func (s *BidService) PlaceAuctionPrice(ctx context.Context, bidID string, placed dto.AuctionPriceDTO) (dto.TenderAuctionDTO, error) {
+	const op = "services.bidService.PlaceAuctionPrice"
+
+	username, err := auth.Username(ctx)
+	if err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("bidID", bidID),
+	)
+
+	bidUUID, err := uuid.Parse(bidID)
+	if err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	price, err := parseDecimal(placed.Price, amountPattern)
+	if err != nil || price.Sign() == 0 {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, repository.ErrInvalidAmount)
+	}
+
+	var auction dto.TenderAuctionDTO
+	err = s.db.WithinTx(ctx, func(ctx context.Context) error {
+		// Rows are locked tender first, then its auction, then the bid, so a price
+		// cannot deadlock with the auction being closed or the bid being decided.
+		bid, err := s.db.GetBid(ctx, bidUUID)
+		if err != nil {
+			return err
+		}
+
+		tender, err := s.db.LockTender(ctx, bid.TenderID)
+		if err != nil {
+			return err
+		}
+
+		if _, err = authorize(ctx, s.db, authz.BidEdit, bidResource(tender, bid)); err != nil {
+			return err
+		}
+
+		current, err := s.db.LockTenderAuction(ctx, tender.ID)
+		if err != nil {
+			return err
+		}
+
+		if bid, err = s.db.LockBid(ctx, bidUUID); err != nil {
+			return err
+		}
+
+		if bid.Status != models.BidStatusPublished {
+			log.Warn("Only published bids take part in the auction", slog.String("status", bid.Status.String()))
+			return repository.ErrIllegalBidTransition
+		}
+
+		now := time.Now()
+		if tender.Status != models.TenderStatusPublished.String() || current.Status != models.AuctionStatusOpen.String() ||
+			now.Before(current.StartsAt) || !now.Before(current.EndsAt) {
+			log.Warn("Auction is not accepting prices", slog.String("status", current.Status))
+			return repository.ErrAuctionNotOpen
+		}
+
+		if err = checkAuctionPrice(current, tender, price); err != nil {
+			return err
+		}
+
+		endsAt := extendAuction(current, tender.SubmissionDeadline, now)
+		if endsAt != nil {
+			log.Info("Extending auction", slog.Time("endsAt", *endsAt))
+		}
+
+		log.Info("Placing auction price")
+
+		auction, err = s.db.PlaceAuctionPrice(ctx, tender.ID, bidUUID, price.FloatString(2), username, endsAt)
+		return err
+	})
+	if err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return auction, nil
+}
+
+// CloseAuction ends an auction whose time is up and picks the bid with the best price
+// as the winner. While the tender is open for decisions, the closing responsible's
+// approval of the winner is submitted as a regular decision; the rest of the quorum
+// approves it with SubmitDecision.
+func (s *TenderService) CloseAuction(ctx context.Context, tenderID string) (dto.TenderAuctionDTO, error) {
+	const op = "services.tenderService.CloseAuction"
+
+	username, err := auth.Username(ctx)
+	if err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("tenderID", tenderID),
+	)
+
+	tenderUUID, err := uuid.Parse(tenderID)
+	if err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var auction dto.TenderAuctionDTO
+	err = s.db.WithinTx(ctx, func(ctx context.Context) error {
+		tender, err := s.db.LockTender(ctx, tenderUUID)
+		if err != nil {
+			return err
+		}
+
+		if _, err = authorize(ctx, s.db, authz.BidDecide, tenderResource(tender)); err != nil {
+			return err
+		}
+
+		current, err := s.db.LockTenderAuction(ctx, tenderUUID)
+		if err != nil {
+			return err
+		}
+
+		if tender.Status == models.TenderStatusCreated.String() || current.Status != models.AuctionStatusOpen.String() {
+			log.Warn("Auction cannot be closed", slog.String("status", current.Status), slog.String("tenderStatus", tender.Status))
+			return repository.ErrAuctionNotOpen
+		}
+		now := time.Now()
+		if now.Before(current.EndsAt) {
+			return repository.ErrAuctionRunning
+		}
+
+		log.Info("Closing auction", slog.Int("prices", current.PriceCount))
+
+		auction, err = s.db.CloseTenderAuction(ctx, tenderUUID, current.LeadingBidID)
+		if err != nil {
+			return err
+		}
+
+		if current.LeadingBidID == nil || tender.Status != models.TenderStatusPublished.String() || decisionOpen(tender, now) != nil {
+			return nil
+		}
+
+		log.Info("Approving auction winner", slog.String("bidID", current.LeadingBidID.String()))
+
+		_, err = s.db.SubmitDecision(ctx, *current.LeadingBidID, models.BidStatusApproved.String(), username)
+		return err
+	})
+	if err != nil {
+		return dto.TenderAuctionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return auction, nil
+}
+
+// CloseEndedAuctions closes the auctions of published and closed tenders whose end has
+// passed and records their leading bids as winners. Prices are only accepted while the
+// tender is published, so without it an auction nobody closes by hand would stay open.
+// It is run periodically by the scheduler.
+func (s *TenderService) CloseEndedAuctions(ctx context.Context) error {
+	const op = "services.tenderService.CloseEndedAuctions"
+
+	log := s.log.With(slog.String("op", op))
+
+	for {
+		closed, err := s.db.CloseEndedAuctions(ctx, scheduledTendersBatch)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, id := range closed {
+			log.Info("Auction closed after its end", slog.String("tenderID", id.String()))
+		}
+
+		if len(closed) < scheduledTendersBatch {
+			return nil
+		}
+	}
+}
+
+// checkAuctionDeadline keeps an open auction within the submission deadline the tender
+// gets after an edit.
+func (s *TenderService) checkAuctionDeadline(ctx context.Context, tenderID uuid.UUID, submission *time.Time) error {
+	auction, err := s.db.GetTenderAuction(ctx, tenderID)
+	if err != nil {
+		if errors.Is(err, repository.ErrAuctionNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if auction.Status == models.AuctionStatusOpen.String() && !endsBySubmission(auction.EndsAt, submission) {
+		return repository.ErrInvalidDeadline
+	}
+	return nil
+}
+
+// checkAuctionDecision keeps decisions on an auction tender until the auction is
+// closed, and then lets only its winner be approved. The auction stays locked so it
+// cannot be closed while the decision is made.
+func (s *BidService) checkAuctionDecision(ctx context.Context, bid models.Bid, decision models.BidStatus) error {
+	auction, err := s.db.LockTenderAuction(ctx, bid.TenderID)
+	if err != nil {
+		if errors.Is(err, repository.ErrAuctionNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if auction.Status != models.AuctionStatusClosed.String() {
+		return repository.ErrAuctionRunning
+	}
+	if decision == models.BidStatusApproved && (auction.WinningBidID == nil || *auction.WinningBidID != bid.ID) {
+		return repository.ErrNotAuctionWinner
+	}
+	return nil
+}
+
+// validateAuction checks the settings of an auction against the submission deadline of
+// its tender and normalizes its decrement.
+func validateAuction(auction *dto.AuctionDTO, submission *time.Time, now time.Time) error {
+	if auction.StartsAt.IsZero() || !auction.EndsAt.After(auction.StartsAt) || !auction.EndsAt.After(now) {
+		return repository.ErrInvalidAuction
+	}
+	if !endsBySubmission(auction.EndsAt, submission) {
+		return repository.ErrInvalidAuction
+	}
+
+	decrement, err := parseDecimal(auction.MinDecrement, amountPattern)
+	if err != nil || decrement.Sign() == 0 {
+		return repository.ErrInvalidAuction
+	}
+	auction.MinDecrement = decrement.FloatString(2)
+
+	window, extension := auction.ExtensionWindowMinutes, auction.ExtensionMinutes
+	if window < 0 || window > maxExtensionMinutes || extension < 0 || extension > maxExtensionMinutes {
+		return repository.ErrInvalidAuction
+	}
+	if (window == 0) != (extension == 0) {
+		return repository.ErrInvalidAuction
+	}
+	return nil
+}
+
+// checkAuctionPrice requires a price to undercut the best price by the minimum
+// decrement and, on a tender capped at its budget, to stay within the maximum.
+func checkAuctionPrice(auction dto.TenderAuctionDTO, tender dto.TenderResponseDTO, price *big.Rat) error {
+	if tender.CapAtBudget && tender.BudgetMax != nil {
+		maximum, ok := new(big.Rat).SetString(*tender.BudgetMax)
+		if ok && price.Cmp(maximum) > 0 {
+			return repository.ErrPriceAboveBudget
+		}
+	}
+
+	if auction.BestPrice == nil {
+		return nil
+	}
+	best, ok := new(big.Rat).SetString(*auction.BestPrice)
+	if !ok {
+		return repository.ErrInvalidAmount
+	}
+	decrement, ok := new(big.Rat).SetString(auction.MinDecrement)
+	if !ok {
+		return repository.ErrInvalidAmount
+	}
+	if price.Cmp(best.Sub(best, decrement)) > 0 {
+		return repository.ErrPriceNotLowEnough
+	}
+	return nil
+}
+
+// endsBySubmission reports whether an auction ending at endsAt is over by the
+// submission deadline. An auction needs a submission deadline to end by.
+func endsBySubmission(endsAt time.Time, submission *time.Time) bool {
+	return submission != nil && !endsAt.After(*submission)
+}
+
+// extendAuction returns the new end of an auction that receives a price at now, or
+// nil when the price does not fall within the extension window. The auction is never
+// extended past the submission deadline.
+func extendAuction(auction dto.TenderAuctionDTO, submission *time.Time, now time.Time) *time.Time {
+	window := time.Duration(auction.ExtensionWindowMinutes) * time.Minute
+	if window == 0 || auction.EndsAt.Sub(now) > window {
+		return nil
+	}
+
+	endsAt := now.Add(time.Duration(auction.ExtensionMinutes) * time.Minute)
+	if submission != nil && endsAt.After(*submission) {
+		endsAt = *submission
+	}
+	if !endsAt.After(auction.EndsAt) {
+		return nil
+	}
+	return &endsAt
+}
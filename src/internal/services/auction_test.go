@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"git.codenrock.com/avito/internal/auth"
+	"git.codenrock.com/avito/internal/authz"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/domain/models"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+	"io"
+	"log/slog"
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCheckAuctionPrice(t *testing.T) {
+	amount := func(s string) *string { return &s }
+	open := dto.TenderResponseDTO{}
+	capped := dto.TenderResponseDTO{BudgetMax: amount("1000.00"), CapAtBudget: true}
+
+	tests := []struct {
+		name    string
+		best    *string
+		tender  dto.TenderResponseDTO
+		price   string
+		wantErr error
+	}{
+		{"first price", nil, open, "5000", nil},
+		{"first price within budget", nil, capped, "1000", nil},
+		{"first price above budget", nil, capped, "1000.01", repository.ErrPriceAboveBudget},
+		{"undercuts by the decrement", amount("900.00"), capped, "890", nil},
+		{"undercuts by more", amount("900.00"), capped, "500", nil},
+		{"undercuts by less", amount("900.00"), capped, "890.01", repository.ErrPriceNotLowEnough},
+		{"equals the best price", amount("900.00"), capped, "900", repository.ErrPriceNotLowEnough},
+		{"above the best price", amount("900.00"), open, "950", repository.ErrPriceNotLowEnough},
+		{"above budget below best", amount("2000.00"), capped, "1500", repository.ErrPriceAboveBudget},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, ok := new(big.Rat).SetString(tt.price)
+			if !ok {
+				t.Fatalf("bad price %q", tt.price)
+			}
+			auction := dto.TenderAuctionDTO{MinDecrement: "10.00", BestPrice: tt.best}
+			if err := checkAuctionPrice(auction, tt.tender, price); !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkAuctionPrice() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateAuction(t *testing.T) {
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	submission := now.Add(48 * time.Hour)
+	valid := dto.AuctionDTO{
+		StartsAt:               now.Add(time.Hour),
+		EndsAt:                 now.Add(24 * time.Hour),
+		MinDecrement:           "5",
+		ExtensionWindowMinutes: 10,
+		ExtensionMinutes:       5,
+	}
+
+	tests := []struct {
+		name       string
+		change     func(a *dto.AuctionDTO)
+		submission *time.Time
+		wantErr    error
+	}{
+		{"valid", func(a *dto.AuctionDTO) {}, &submission, nil},
+		{"ends at the submission deadline", func(a *dto.AuctionDTO) { a.EndsAt = submission }, &submission, nil},
+		{"without extensions", func(a *dto.AuctionDTO) { a.ExtensionWindowMinutes, a.ExtensionMinutes = 0, 0 }, &submission, nil},
+		{"ends after the submission deadline", func(a *dto.AuctionDTO) { a.EndsAt = submission.Add(time.Second) }, &submission, repository.ErrInvalidAuction},
+		{"no submission deadline", func(a *dto.AuctionDTO) {}, nil, repository.ErrInvalidAuction},
+		{"no start", func(a *dto.AuctionDTO) { a.StartsAt = time.Time{} }, &submission, repository.ErrInvalidAuction},
+		{"ends before it starts", func(a *dto.AuctionDTO) { a.EndsAt = a.StartsAt }, &submission, repository.ErrInvalidAuction},
+		{"already ended", func(a *dto.AuctionDTO) { a.StartsAt, a.EndsAt = now.Add(-2*time.Hour), now }, &submission, repository.ErrInvalidAuction},
+		{"zero decrement", func(a *dto.AuctionDTO) { a.MinDecrement = "0" }, &submission, repository.ErrInvalidAuction},
+		{"negative decrement", func(a *dto.AuctionDTO) { a.MinDecrement = "-5" }, &submission, repository.ErrInvalidAuction},
+		{"decrement with three decimals", func(a *dto.AuctionDTO) { a.MinDecrement = "0.001" }, &submission, repository.ErrInvalidAuction},
+		{"window without extension", func(a *dto.AuctionDTO) { a.ExtensionMinutes = 0 }, &submission, repository.ErrInvalidAuction},
+		{"extension without window", func(a *dto.AuctionDTO) { a.ExtensionWindowMinutes = 0 }, &submission, repository.ErrInvalidAuction},
+		{"window too long", func(a *dto.AuctionDTO) { a.ExtensionWindowMinutes = maxExtensionMinutes + 1 }, &submission, repository.ErrInvalidAuction},
+		{"extension too long", func(a *dto.AuctionDTO) { a.ExtensionMinutes = maxExtensionMinutes + 1 }, &submission, repository.ErrInvalidAuction},
+		{"negative window", func(a *dto.AuctionDTO) { a.ExtensionWindowMinutes = -1 }, &submission, repository.ErrInvalidAuction},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auction := valid
+			tt.change(&auction)
+			if err := validateAuction(&auction, tt.submission, now); !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateAuction() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("normalizes the decrement", func(t *testing.T) {
+		auction := valid
+		if err := validateAuction(&auction, &submission, now); err != nil {
+			t.Fatalf("validateAuction() error = %v", err)
+		}
+		if auction.MinDecrement != "5.00" {
+			t.Errorf("MinDecrement = %s, want 5.00", auction.MinDecrement)
+		}
+	})
+}
+
+func TestExtendAuction(t *testing.T) {
+	endsAt := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	auction := dto.TenderAuctionDTO{EndsAt: endsAt, ExtensionWindowMinutes: 10, ExtensionMinutes: 15}
+	later := endsAt.Add(time.Hour)
+	deadline := endsAt.Add(5 * time.Minute)
+
+	tests := []struct {
+		name       string
+		auction    dto.TenderAuctionDTO
+		now        time.Time
+		submission *time.Time
+		want       *time.Time
+	}{
+		{"before the window", auction, endsAt.Add(-11 * time.Minute), &later, nil},
+		{"at the start of the window", auction, endsAt.Add(-10 * time.Minute), &later, ptr(endsAt.Add(5 * time.Minute))},
+		{"late in the window", auction, endsAt.Add(-time.Minute), &later, ptr(endsAt.Add(14 * time.Minute))},
+		{"without extensions", dto.TenderAuctionDTO{EndsAt: endsAt}, endsAt.Add(-time.Minute), &later, nil},
+		{"extension shorter than the time left", dto.TenderAuctionDTO{EndsAt: endsAt, ExtensionWindowMinutes: 10, ExtensionMinutes: 5},
+			endsAt.Add(-8 * time.Minute), &later, nil},
+		{"capped at the submission deadline", auction, endsAt.Add(-time.Minute), &deadline, &deadline},
+		{"already at the submission deadline", auction, endsAt.Add(-time.Minute), &endsAt, nil},
+		{"no submission deadline", auction, endsAt.Add(-time.Minute), nil, ptr(endsAt.Add(14 * time.Minute))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := extendAuction(tt.auction, tt.submission, tt.now)
+			switch {
+			case got == nil && tt.want == nil:
+			case got == nil || tt.want == nil || !got.Equal(*tt.want):
+				t.Errorf("extendAuction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// lockingStorage records the order in which a service locks rows.
+type lockingStorage struct {
+	BidStorage
+
+	tender  dto.TenderResponseDTO
+	bid     models.Bid
+	auction dto.TenderAuctionDTO
+	subject authz.Subject
+	locks   []string
+}
+
+func (f *lockingStorage) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (f *lockingStorage) GetSubject(context.Context, string) (authz.Subject, error) {
+	return f.subject, nil
+}
+
+func (f *lockingStorage) GetBid(context.Context, uuid.UUID) (models.Bid, error) {
+	return f.bid, nil
+}
+
+func (f *lockingStorage) LockTender(context.Context, uuid.UUID) (dto.TenderResponseDTO, error) {
+	f.locks = append(f.locks, "tender")
+	return f.tender, nil
+}
+
+func (f *lockingStorage) LockTenderAuction(context.Context, uuid.UUID) (dto.TenderAuctionDTO, error) {
+	f.locks = append(f.locks, "auction")
+	return f.auction, nil
+}
+
+func (f *lockingStorage) LockBid(context.Context, uuid.UUID) (models.Bid, error) {
+	f.locks = append(f.locks, "bid")
+	return f.bid, nil
+}
+
+func (f *lockingStorage) PlaceAuctionPrice(context.Context, uuid.UUID, uuid.UUID, string, string, *time.Time) (dto.TenderAuctionDTO, error) {
+	f.locks = append(f.locks, "bid")
+	return f.auction, nil
+}
+
+func (f *lockingStorage) SubmitDecision(_ context.Context, bidID uuid.UUID, decision, _ string) (dto.BidResponseDTO, error) {
+	f.locks = append(f.locks, "bid")
+	return dto.BidResponseDTO{ID: bidID, Status: decision}, nil
+}
+
+func TestAuctionLockOrder(t *testing.T) {
+	buyerOrg, supplierOrg := uuid.New(), uuid.New()
+	submission := time.Now().Add(24 * time.Hour)
+	tender := dto.TenderResponseDTO{
+		ID:                 uuid.New(),
+		Status:             models.TenderStatusPublished.String(),
+		OrganizationID:     buyerOrg,
+		Currency:           "RUB",
+		SubmissionDeadline: &submission,
+	}
+	bid := models.Bid{
+		ID:             uuid.New(),
+		Status:         models.BidStatusPublished,
+		TenderID:       tender.ID,
+		OrganizationID: supplierOrg,
+		AuthorType:     "Organization",
+		AuthorID:       supplierOrg,
+	}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	want := []string{"tender", "auction", "bid", "bid"}
+
+	t.Run("placing a price", func(t *testing.T) {
+		db := &lockingStorage{
+			tender: tender,
+			bid:    bid,
+			auction: dto.TenderAuctionDTO{
+				Status:       models.AuctionStatusOpen.String(),
+				StartsAt:     time.Now().Add(-time.Hour),
+				EndsAt:       time.Now().Add(time.Hour),
+				MinDecrement: "1.00",
+			},
+			subject: authz.Subject{Username: "supplier", Organizations: []uuid.UUID{supplierOrg}},
+		}
+		ctx := auth.WithPrincipal(context.Background(), auth.Principal{Username: "supplier"})
+
+		if _, err := NewBidService(log, db).PlaceAuctionPrice(ctx, bid.ID.String(), dto.AuctionPriceDTO{Price: "100"}); err != nil {
+			t.Fatalf("PlaceAuctionPrice() error = %v", err)
+		}
+		if !slices.Equal(db.locks, want) {
+			t.Errorf("locks = %v, want %v", db.locks, want)
+		}
+	})
+
+	t.Run("deciding on the winner", func(t *testing.T) {
+		db := &lockingStorage{
+			tender:  tender,
+			bid:     bid,
+			auction: dto.TenderAuctionDTO{Status: models.AuctionStatusClosed.String(), WinningBidID: &bid.ID},
+			subject: authz.Subject{Username: "buyer", Organizations: []uuid.UUID{buyerOrg}},
+		}
+		ctx := auth.WithPrincipal(context.Background(), auth.Principal{Username: "buyer"})
+
+		if _, err := NewBidService(log, db).SubmitDecision(ctx, bid.ID.String(), models.BidStatusApproved.String()); err != nil {
+			t.Fatalf("SubmitDecision() error = %v", err)
+		}
+		if !slices.Equal(db.locks, want) {
+			t.Errorf("locks = %v, want %v", db.locks, want)
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"git.codenrock.com/avito/internal/auth"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/domain/models"
+	"git.codenrock.com/avito/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+	"log/slog"
+	"time"
+)
+
+type AuthStorage interface {
+	GetEmployeeCredentials(ctx context.Context, username string) (models.User, string, error)
+}
+
+type TokenIssuer interface {
+	Issue(p auth.Principal) (string, time.Time, error)
+}
+
+type AuthService struct {
+	log    *slog.Logger
+	db     AuthStorage
+	tokens TokenIssuer
+}
+
+var (
+	ErrInvalidCredentials = fmt.Errorf("invalid username or password")
+)
+
+func NewAuthService(log *slog.Logger, db AuthStorage, tokens TokenIssuer) *AuthService {
+	return &AuthService{
+		log:    log,
+		db:     db,
+		tokens: tokens,
+	}
+}
+
+func (s *AuthService) Login(ctx context.Context, username, password string) (dto.TokenDTO, error) {
+	const op = "services.authService.Login"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("username", username),
+	)
+
+	if username == "" || password == "" {
+		return dto.TokenDTO{}, fmt.Errorf("%s: %w", op, ErrInvalidCredentials)
+	}
+
+	user, passwordHash, err := s.db.GetEmployeeCredentials(ctx, username)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			log.Warn("Login for unknown user")
+			return dto.TokenDTO{}, fmt.Errorf("%s: %w", op, ErrInvalidCredentials)
+		}
+		return dto.TokenDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if passwordHash == "" {
+		log.Warn("Login for user without password")
+		return dto.TokenDTO{}, fmt.Errorf("%s: %w", op, ErrInvalidCredentials)
+	}
+
+	if err = bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(password)); err != nil {
+		log.Warn("Invalid password")
+		return dto.TokenDTO{}, fmt.Errorf("%s: %w", op, ErrInvalidCredentials)
+	}
+
+	token, expiresAt, err := s.tokens.Issue(auth.Principal{ID: user.ID, Username: user.Username})
+	if err != nil {
+		return dto.TokenDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("Token issued")
+
+	return dto.TokenDTO{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresAt:   expiresAt,
+	}, nil
+}
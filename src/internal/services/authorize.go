@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"git.codenrock.com/avito/internal/auth"
+	"git.codenrock.com/avito/internal/authz"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/domain/models"
+	"git.codenrock.com/avito/internal/repository"
+	"time"
+)
+
+type SubjectStorage interface {
+	GetSubject(ctx context.Context, username string) (authz.Subject, error)
+}
+
+// currentSubject loads the caller from the request principal. Anonymous callers get
+// the zero Subject; a principal naming an unknown employee is unauthenticated.
+func currentSubject(ctx context.Context, db SubjectStorage) (authz.Subject, error) {
+	username, err := auth.Username(ctx)
+	if err != nil {
+		return authz.Subject{}, nil
+	}
+
+	subject, err := db.GetSubject(ctx, username)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return authz.Subject{}, auth.ErrUnauthenticated
+		}
+		return authz.Subject{}, err
+	}
+
+	return subject, nil
+}
+
+// authorize checks the permission for the caller and returns the loaded subject.
+func authorize(ctx context.Context, db SubjectStorage, perm authz.Permission, res authz.Resource) (authz.Subject, error) {
+	subject, err := currentSubject(ctx, db)
+	if err != nil {
+		return authz.Subject{}, err
+	}
+
+	if !authz.Can(subject, perm, res) {
+		if subject.IsAnonymous() {
+			return authz.Subject{}, auth.ErrUnauthenticated
+		}
+		return authz.Subject{}, authz.ErrForbidden
+	}
+
+	return subject, nil
+}
+
+func tenderResource(tender dto.TenderResponseDTO) authz.Resource {
+	return authz.Resource{
+		TenderOrganizationID: tender.OrganizationID,
+		TenderCreator:        tender.CreatorUsername,
+		TenderPublished:      tender.Status == models.TenderStatusPublished.String(),
+
+		TenderInviteOnly:           tender.Visibility == models.TenderVisibilityInviteOnly.String(),
+		TenderInvitedOrganizations: tender.InvitedOrganizations,
+	}
+}
+
+func bidResource(tender dto.TenderResponseDTO, bid models.Bid) authz.Resource {
+	res := tenderResource(tender)
+	res.BidOrganizationID = bid.OrganizationID
+	res.BidAuthorType = bid.AuthorType
+	res.BidAuthorID = bid.AuthorID
+	res.BidsSealed = bidsSealed(tender, time.Now())
+	return res
+}
@@ -3,22 +3,44 @@ package services
 import (
 	"context"
 	"fmt"
+	"git.codenrock.com/avito/internal/auth"
+	"git.codenrock.com/avito/internal/authz"
 	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/domain/models"
+	"git.codenrock.com/avito/internal/repository"
 	"github.com/google/uuid"
 	"log/slog"
+	"time"
 )
 
 type BidStorage interface {
+	TxManager
+	SubjectStorage
 	CreateBid(ctx context.Context, bid *dto.BidDTO) (dto.BidResponseDTO, error)
-	GetBidsByUsername(ctx context.Context, username string, limit, offset int) ([]dto.BidResponseDTO, error)
-	GetTenderBids(ctx context.Context, tenderID string, limit, offset int) ([]dto.BidResponseDTO, error)
-	GetBidStatus(ctx context.Context, bidID uuid.UUID, username string) (string, error)
+	GetBidsByUsername(ctx context.Context, username string, q dto.ListQueryDTO) (dto.PageDTO[dto.BidResponseDTO], error)
+	GetTenderBids(ctx context.Context, tenderID uuid.UUID, q dto.ListQueryDTO) (dto.PageDTO[dto.BidResponseDTO], error)
 	UpdateBid(ctx context.Context, bidID uuid.UUID, username string, updates dto.UpdateBidDTO) (dto.BidResponseDTO, error)
-	UpdateBidStatus(ctx context.Context, bidID uuid.UUID, status string, username string) (dto.BidResponseDTO, error)
+	GetBid(ctx context.Context, bidID uuid.UUID) (models.Bid, error)
+	LockBid(ctx context.Context, bidID uuid.UUID) (models.Bid, error)
+	LockTender(ctx context.Context, tenderID uuid.UUID) (dto.TenderResponseDTO, error)
+	GetTender(ctx context.Context, tenderID uuid.UUID) (dto.TenderResponseDTO, error)
+	UpdateBidStatus(ctx context.Context, bidID uuid.UUID, from, to models.BidStatus, username string) (dto.BidResponseDTO, error)
 	SubmitDecision(ctx context.Context, bidID uuid.UUID, decision, username string) (dto.BidResponseDTO, error)
-	SendFeedback(ctx context.Context, bidID uuid.UUID, feedback, username string) (dto.BidResponseDTO, error)
+	GetBidDecisions(ctx context.Context, bidID uuid.UUID) (dto.BidDecisionsDTO, error)
+	SendFeedback(ctx context.Context, bidID uuid.UUID, feedback string, reviewerID, organizationID uuid.UUID) (dto.BidResponseDTO, error)
 	RollbackBidVersion(ctx context.Context, bidID uuid.UUID, version int, username string) (dto.BidResponseDTO, error)
-	GetBidReviews(ctx context.Context, tenderID uuid.UUID, authorUsername, requesterUsername string, limit, offset int) ([]dto.BidReviewDTO, error)
+	GetBidReviews(ctx context.Context, tenderID uuid.UUID, authorUsername string, q dto.ListQueryDTO) (dto.PageDTO[dto.BidReviewDTO], error)
+	GetBidVersions(ctx context.Context, bidID uuid.UUID) ([]dto.VersionDTO, error)
+	SaveBidScores(ctx context.Context, bidID, tenderID, userID uuid.UUID, scores []dto.ScoreDTO) ([]dto.BidScoreDTO, error)
+	GetBidScores(ctx context.Context, bidID uuid.UUID) ([]dto.BidScoreDTO, error)
+	GetTenderCriteria(ctx context.Context, tenderID uuid.UUID) ([]dto.TenderCriterionDTO, error)
+	GetBidsScoreboard(ctx context.Context, tenderID uuid.UUID, bidIDs []uuid.UUID) ([]dto.BidRankingDTO, error)
+	GetComparedBids(ctx context.Context, tenderID uuid.UUID, bidIDs []uuid.UUID) ([]dto.ComparedBidDTO, error)
+	WithdrawBid(ctx context.Context, bidID uuid.UUID, reason, username string) (dto.BidResponseDTO, error)
+	ResubmitBid(ctx context.Context, bidID uuid.UUID, username string) (dto.BidResponseDTO, error)
+	GetTenderAuction(ctx context.Context, tenderID uuid.UUID) (dto.TenderAuctionDTO, error)
+	LockTenderAuction(ctx context.Context, tenderID uuid.UUID) (dto.TenderAuctionDTO, error)
+	PlaceAuctionPrice(ctx context.Context, tenderID, bidID uuid.UUID, price, username string, endsAt *time.Time) (dto.TenderAuctionDTO, error)
 }
 
 type BidService struct {
@@ -50,9 +72,31 @@ func (s *BidService) CreateBid(ctx context.Context, bid *dto.BidDTO) (dto.BidRes
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, ErrUsernameFieldEmpty)
 	}
 
+	if err := s.authorizeBidAuthor(ctx, bid); err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log.Info("Creating bid")
 
-	bidResponse, err := s.db.CreateBid(ctx, bid)
+	var bidResponse dto.BidResponseDTO
+	err := s.db.WithinTx(ctx, func(ctx context.Context) error {
+		tender, err := s.db.GetTender(ctx, bid.TenderID)
+		if err != nil {
+			return err
+		}
+
+		if err = submissionOpen(tender, time.Now()); err != nil {
+			log.Warn("Submission deadline has passed", slog.String("tenderID", tender.ID.String()))
+			return err
+		}
+
+		if err = priceBid(bid, tender); err != nil {
+			return err
+		}
+
+		bidResponse, err = s.db.CreateBid(ctx, bid)
+		return err
+	})
 	if err != nil {
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
@@ -62,9 +106,14 @@ func (s *BidService) CreateBid(ctx context.Context, bid *dto.BidDTO) (dto.BidRes
 	return bidResponse, nil
 }
 
-func (s *BidService) GetUserBids(ctx context.Context, username string, limit, offset int) ([]dto.BidResponseDTO, error) {
+func (s *BidService) GetUserBids(ctx context.Context, q dto.ListQueryDTO) (dto.PageDTO[dto.BidResponseDTO], error) {
 	const op = "services.bidService.GetUserBids"
 
+	username, err := auth.Username(ctx)
+	if err != nil {
+		return dto.PageDTO[dto.BidResponseDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log := s.log.With(
 		slog.String("op", op),
 		slog.String("username", username),
@@ -72,9 +121,9 @@ func (s *BidService) GetUserBids(ctx context.Context, username string, limit, of
 
 	log.Info("Getting user bids")
 
-	bids, err := s.db.GetBidsByUsername(ctx, username, limit, offset)
+	bids, err := s.db.GetBidsByUsername(ctx, username, q)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return dto.PageDTO[dto.BidResponseDTO]{}, fmt.Errorf("%s: %w", op, err)
 	}
 
 	log.Info("Got user bids")
@@ -82,7 +131,7 @@ func (s *BidService) GetUserBids(ctx context.Context, username string, limit, of
 	return bids, nil
 }
 
-func (s *BidService) GetTenderBids(ctx context.Context, tenderID, username string, limit, offset int) ([]dto.BidResponseDTO, error) {
+func (s *BidService) GetTenderBids(ctx context.Context, tenderID string, q dto.ListQueryDTO) (dto.PageDTO[dto.BidResponseDTO], error) {
 	const op = "services.bidService.GetTenderBids"
 
 	log := s.log.With(
@@ -92,14 +141,33 @@ func (s *BidService) GetTenderBids(ctx context.Context, tenderID, username strin
 
 	if tenderID == "" {
 		log.Error("tenderID is empty")
-		return nil, fmt.Errorf("%s: %w", op, ErrTenderIDFieldEmpty)
+		return dto.PageDTO[dto.BidResponseDTO]{}, fmt.Errorf("%s: %w", op, ErrTenderIDFieldEmpty)
+	}
+
+	tenderUUID, err := uuid.Parse(tenderID)
+	if err != nil {
+		return dto.PageDTO[dto.BidResponseDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tender, err := s.db.GetTender(ctx, tenderUUID)
+	if err != nil {
+		return dto.PageDTO[dto.BidResponseDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = authorize(ctx, s.db, authz.BidList, tenderResource(tender)); err != nil {
+		return dto.PageDTO[dto.BidResponseDTO]{}, fmt.Errorf("%s: %w", op, err)
 	}
 
 	log.Info("Getting tender bids")
 
-	bids, err := s.db.GetTenderBids(ctx, tenderID, limit, offset)
+	bids, err := s.db.GetTenderBids(ctx, tenderUUID, q)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return dto.PageDTO[dto.BidResponseDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if bidsSealed(tender, time.Now()) {
+		log.Info("Bids are sealed until the submission deadline")
+		bids.Items = sealBids(bids.Items)
 	}
 
 	log.Info("Got tender bids")
@@ -107,7 +175,7 @@ func (s *BidService) GetTenderBids(ctx context.Context, tenderID, username strin
 	return bids, nil
 }
 
-func (s *BidService) GetBidStatus(ctx context.Context, bidID string, username string) (string, error) {
+func (s *BidService) GetBidStatus(ctx context.Context, bidID string) (string, int, error) {
 	const op = "services.bidService.GetBidStatus"
 
 	log := s.log.With(
@@ -117,24 +185,33 @@ func (s *BidService) GetBidStatus(ctx context.Context, bidID string, username st
 
 	bidUUID, err := uuid.Parse(bidID)
 	if err != nil {
-		return "", fmt.Errorf("%s: %w", op, err)
+		return "", 0, fmt.Errorf("%s: %w", op, err)
 	}
 
 	log.Info("Getting bid status")
 
-	status, err := s.db.GetBidStatus(ctx, bidUUID, username)
+	bid, err := s.db.GetBid(ctx, bidUUID)
 	if err != nil {
-		return "", fmt.Errorf("%s: %w", op, err)
+		return "", 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.authorizeBid(ctx, bid, authz.BidView); err != nil {
+		return "", 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	log.Info("Got bid status", slog.String("status", status))
+	log.Info("Got bid status", slog.String("status", bid.Status.String()))
 
-	return status, nil
+	return bid.Status.String(), bid.Version, nil
 }
 
-func (s *BidService) UpdateBid(ctx context.Context, bidID string, username string, updates dto.UpdateBidDTO) (dto.BidResponseDTO, error) {
+func (s *BidService) UpdateBid(ctx context.Context, bidID string, updates dto.UpdateBidDTO, expectedVersion int) (dto.BidResponseDTO, error) {
 	const op = "services.bidService.UpdateBid"
 
+	username, err := auth.Username(ctx)
+	if err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log := s.log.With(
 		slog.String("op", op),
 		slog.String("bidID", bidID),
@@ -145,9 +222,45 @@ func (s *BidService) UpdateBid(ctx context.Context, bidID string, username strin
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	log.Info("Updating bid")
-
-	bidResponse, err := s.db.UpdateBid(ctx, bidUUID, username, updates)
+	var bidResponse dto.BidResponseDTO
+	err = s.db.WithinTx(ctx, func(ctx context.Context) error {
+		bid, err := s.db.LockBid(ctx, bidUUID)
+		if err != nil {
+			return err
+		}
+
+		if err = s.authorizeBid(ctx, bid, authz.BidEdit); err != nil {
+			return err
+		}
+
+		if err = checkExpectedVersion(bid.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		if bid.Status.IsDecided() {
+			log.Warn("Cannot edit decided bid", slog.String("status", bid.Status.String()))
+			return repository.ErrBidAlreadyDecided
+		}
+		if !bid.Status.IsEditable() {
+			log.Warn("Cannot edit bid in its status", slog.String("status", bid.Status.String()))
+			return repository.ErrIllegalBidTransition
+		}
+
+		tender, err := s.db.GetTender(ctx, bid.TenderID)
+		if err != nil {
+			return err
+		}
+
+		if err = submissionOpen(tender, time.Now()); err != nil {
+			log.Warn("Submission deadline has passed", slog.String("tenderID", tender.ID.String()))
+			return err
+		}
+
+		log.Info("Updating bid")
+
+		bidResponse, err = s.db.UpdateBid(ctx, bidUUID, username, updates)
+		return err
+	})
 	if err != nil {
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
@@ -157,9 +270,14 @@ func (s *BidService) UpdateBid(ctx context.Context, bidID string, username strin
 	return bidResponse, nil
 }
 
-func (s *BidService) UpdateBidStatus(ctx context.Context, bidID string, status string, username string) (dto.BidResponseDTO, error) {
+func (s *BidService) UpdateBidStatus(ctx context.Context, bidID string, status string, expectedVersion int) (dto.BidResponseDTO, error) {
 	const op = "services.bidService.UpdateBidStatus"
 
+	username, err := auth.Username(ctx)
+	if err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log := s.log.With(
 		slog.String("op", op),
 		slog.String("bidID", bidID),
@@ -170,9 +288,36 @@ func (s *BidService) UpdateBidStatus(ctx context.Context, bidID string, status s
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	log.Info("Updating bid status")
+	next, ok := models.ParseBidStatus(status)
+	if !ok {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrInvalidBidStatus)
+	}
+
+	var bidResponse dto.BidResponseDTO
+	err = s.db.WithinTx(ctx, func(ctx context.Context) error {
+		bid, err := s.db.LockBid(ctx, bidUUID)
+		if err != nil {
+			return err
+		}
+
+		if err = s.authorizeBid(ctx, bid, authz.BidEdit); err != nil {
+			return err
+		}
+
+		if err = checkExpectedVersion(bid.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		if !bid.Status.CanTransitionTo(next, models.BidActorAuthor) {
+			log.Warn("Illegal bid status transition", slog.String("from", bid.Status.String()), slog.String("to", next.String()))
+			return repository.ErrIllegalBidTransition
+		}
+
+		log.Info("Updating bid status", slog.String("from", bid.Status.String()), slog.String("to", next.String()))
 
-	bidResponse, err := s.db.UpdateBidStatus(ctx, bidUUID, status, username)
+		bidResponse, err = s.db.UpdateBidStatus(ctx, bidUUID, bid.Status, next, username)
+		return err
+	})
 	if err != nil {
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
@@ -182,9 +327,14 @@ func (s *BidService) UpdateBidStatus(ctx context.Context, bidID string, status s
 	return bidResponse, nil
 }
 
-func (s *BidService) SubmitDecision(ctx context.Context, bidID string, decision string, username string) (dto.BidResponseDTO, error) {
+func (s *BidService) SubmitDecision(ctx context.Context, bidID string, decision string) (dto.BidResponseDTO, error) {
 	const op = "services.bidService.SubmitDecision"
 
+	username, err := auth.Username(ctx)
+	if err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log := s.log.With(
 		slog.String("op", op),
 		slog.String("bidID", bidID),
@@ -195,9 +345,63 @@ func (s *BidService) SubmitDecision(ctx context.Context, bidID string, decision
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	log.Info("Submitting decision")
+	next, ok := models.ParseBidStatus(decision)
+	if !ok || !next.IsDecided() {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrInvalidBidStatus)
+	}
 
-	bidResponse, err := s.db.SubmitDecision(ctx, bidUUID, decision, username)
+	var bidResponse dto.BidResponseDTO
+	err = s.db.WithinTx(ctx, func(ctx context.Context) error {
+		// Rows are locked tender first, then its auction, then the bid, like every
+		// other path that touches more than one of them.
+		bid, err := s.db.GetBid(ctx, bidUUID)
+		if err != nil {
+			return err
+		}
+
+		tender, err := s.db.LockTender(ctx, bid.TenderID)
+		if err != nil {
+			return err
+		}
+
+		if _, err = authorize(ctx, s.db, authz.BidDecide, bidResource(tender, bid)); err != nil {
+			return err
+		}
+
+		if bidsSealed(tender, time.Now()) {
+			log.Warn("Decision on sealed bid")
+			return repository.ErrBidsSealed
+		}
+
+		if err = s.checkAuctionDecision(ctx, bid, next); err != nil {
+			log.Warn("Decision on auction bid", slog.String("error", err.Error()))
+			return err
+		}
+
+		if bid, err = s.db.LockBid(ctx, bidUUID); err != nil {
+			return err
+		}
+
+		if !bid.Status.CanTransitionTo(next, models.BidActorReviewer) {
+			log.Warn("Decision on bid in illegal status", slog.String("status", bid.Status.String()))
+			return repository.ErrIllegalBidTransition
+		}
+
+		if tender.Status != models.TenderStatusPublished.String() {
+			log.Warn("Decision on bid of unpublished tender", slog.String("tenderStatus", tender.Status))
+			return repository.ErrIllegalBidTransition
+		}
+
+		if err = decisionOpen(tender, time.Now()); err != nil {
+			log.Warn("Decision after decision deadline")
+			return err
+		}
+
+		log.Info("Submitting decision")
+
+		bidResponse, err = s.db.SubmitDecision(ctx, bidUUID, decision, username)
+		return err
+	})
 	if err != nil {
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
@@ -207,7 +411,41 @@ func (s *BidService) SubmitDecision(ctx context.Context, bidID string, decision
 	return bidResponse, nil
 }
 
-func (s *BidService) SendFeedback(ctx context.Context, bidID string, feedback, username string) (dto.BidResponseDTO, error) {
+func (s *BidService) GetBidDecisions(ctx context.Context, bidID string) (dto.BidDecisionsDTO, error) {
+	const op = "services.bidService.GetBidDecisions"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("bidID", bidID),
+	)
+
+	bidUUID, err := uuid.Parse(bidID)
+	if err != nil {
+		return dto.BidDecisionsDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	bid, err := s.db.GetBid(ctx, bidUUID)
+	if err != nil {
+		return dto.BidDecisionsDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.authorizeBid(ctx, bid, authz.BidDecide); err != nil {
+		return dto.BidDecisionsDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("Getting bid decisions")
+
+	decisions, err := s.db.GetBidDecisions(ctx, bidUUID)
+	if err != nil {
+		return dto.BidDecisionsDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("Got bid decisions", slog.Int("approvals", decisions.Approvals), slog.Int("quorum", decisions.Quorum))
+
+	return decisions, nil
+}
+
+func (s *BidService) SendFeedback(ctx context.Context, bidID string, feedback string) (dto.BidResponseDTO, error) {
 	const op = "services.bidService.SendFeedback"
 
 	log := s.log.With(
@@ -220,9 +458,28 @@ func (s *BidService) SendFeedback(ctx context.Context, bidID string, feedback, u
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
+	bid, err := s.db.GetBid(ctx, bidUUID)
+	if err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tender, err := s.db.GetTender(ctx, bid.TenderID)
+	if err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	reviewer, err := authorize(ctx, s.db, authz.ReviewWrite, bidResource(tender, bid))
+	if err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if bidsSealed(tender, time.Now()) {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrBidsSealed)
+	}
+
 	log.Info("Sending feedback")
 
-	bidResponse, err := s.db.SendFeedback(ctx, bidUUID, feedback, username)
+	bidResponse, err := s.db.SendFeedback(ctx, bidUUID, feedback, reviewer.UserID, tender.OrganizationID)
 	if err != nil {
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
@@ -232,9 +489,14 @@ func (s *BidService) SendFeedback(ctx context.Context, bidID string, feedback, u
 	return bidResponse, nil
 }
 
-func (s *BidService) RollbackBidVersion(ctx context.Context, bidID string, version int, username string) (dto.BidResponseDTO, error) {
+func (s *BidService) RollbackBidVersion(ctx context.Context, bidID string, version int, expectedVersion int) (dto.BidResponseDTO, error) {
 	const op = "services.bidService.RollbackBidVersion"
 
+	username, err := auth.Username(ctx)
+	if err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log := s.log.With(
 		slog.String("op", op),
 		slog.String("bidID", bidID),
@@ -245,9 +507,42 @@ func (s *BidService) RollbackBidVersion(ctx context.Context, bidID string, versi
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	log.Info("Rolling back bid version")
-
-	bidResponse, err := s.db.RollbackBidVersion(ctx, bidUUID, version, username)
+	var bidResponse dto.BidResponseDTO
+	err = s.db.WithinTx(ctx, func(ctx context.Context) error {
+		bid, err := s.db.LockBid(ctx, bidUUID)
+		if err != nil {
+			return err
+		}
+
+		if err = s.authorizeBid(ctx, bid, authz.BidEdit); err != nil {
+			return err
+		}
+
+		if err = checkExpectedVersion(bid.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		if bid.Status.IsDecided() {
+			log.Warn("Cannot roll back decided bid", slog.String("status", bid.Status.String()))
+			return repository.ErrBidAlreadyDecided
+		}
+
+		tender, err := s.db.GetTender(ctx, bid.TenderID)
+		if err != nil {
+			return err
+		}
+
+		// A rollback restores the price and line items, so it is an edit of the bid.
+		if err = submissionOpen(tender, time.Now()); err != nil {
+			log.Warn("Cannot roll back bid after submission deadline")
+			return err
+		}
+
+		log.Info("Rolling back bid version")
+
+		bidResponse, err = s.db.RollbackBidVersion(ctx, bidUUID, version, username)
+		return err
+	})
 	if err != nil {
 		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
@@ -257,7 +552,7 @@ func (s *BidService) RollbackBidVersion(ctx context.Context, bidID string, versi
 	return bidResponse, nil
 }
 
-func (s *BidService) GetBidReviews(ctx context.Context, tenderID, authorUsername, requesterUsername string, limit, offset int) ([]dto.BidReviewDTO, error) {
+func (s *BidService) GetBidReviews(ctx context.Context, tenderID, authorUsername string, q dto.ListQueryDTO) (dto.PageDTO[dto.BidReviewDTO], error) {
 	const op = "services.bidService.GetBidReviews"
 
 	log := s.log.With(
@@ -267,17 +562,122 @@ func (s *BidService) GetBidReviews(ctx context.Context, tenderID, authorUsername
 
 	tenderUUID, err := uuid.Parse(tenderID)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return dto.PageDTO[dto.BidReviewDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tender, err := s.db.GetTender(ctx, tenderUUID)
+	if err != nil {
+		return dto.PageDTO[dto.BidReviewDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = authorize(ctx, s.db, authz.ReviewRead, tenderResource(tender)); err != nil {
+		return dto.PageDTO[dto.BidReviewDTO]{}, fmt.Errorf("%s: %w", op, err)
 	}
 
 	log.Info("Getting bid reviews")
 
-	reviews, err := s.db.GetBidReviews(ctx, tenderUUID, authorUsername, requesterUsername, limit, offset)
+	reviews, err := s.db.GetBidReviews(ctx, tenderUUID, authorUsername, q)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return dto.PageDTO[dto.BidReviewDTO]{}, fmt.Errorf("%s: %w", op, err)
 	}
 
 	log.Info("Got bid reviews")
 
 	return reviews, nil
 }
+
+func (s *BidService) GetBidVersions(ctx context.Context, bidID string) ([]dto.VersionDTO, error) {
+	const op = "services.bidService.GetBidVersions"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("bidID", bidID),
+	)
+
+	bidUUID, err := uuid.Parse(bidID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	bid, err := s.db.GetBid(ctx, bidUUID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.authorizeBid(ctx, bid, authz.BidView); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("Getting bid versions")
+
+	versions, err := s.db.GetBidVersions(ctx, bidUUID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return versions, nil
+}
+
+func (s *BidService) GetBidVersion(ctx context.Context, bidID string, version int) (dto.VersionDTO, error) {
+	const op = "services.bidService.GetBidVersion"
+
+	versions, err := s.GetBidVersions(ctx, bidID)
+	if err != nil {
+		return dto.VersionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	v, ok := findVersion(versions, version)
+	if !ok {
+		return dto.VersionDTO{}, fmt.Errorf("%s: %w", op, repository.ErrVersionNotFound)
+	}
+
+	return v, nil
+}
+
+func (s *BidService) GetBidDiff(ctx context.Context, bidID string, from, to int) (dto.VersionDiffDTO, error) {
+	const op = "services.bidService.GetBidDiff"
+
+	versions, err := s.GetBidVersions(ctx, bidID)
+	if err != nil {
+		return dto.VersionDiffDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	diff, err := diffVersions(versions, from, to)
+	if err != nil {
+		return dto.VersionDiffDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return diff, nil
+}
+
+// authorizeBidAuthor checks that the caller may submit a bid on behalf of its author.
+// In compatibility mode an unauthenticated caller is trusted, as before.
+func (s *BidService) authorizeBidAuthor(ctx context.Context, bid *dto.BidDTO) error {
+	if _, ok := auth.PrincipalFromContext(ctx); !ok && auth.IsCompat(ctx) {
+		return nil
+	}
+
+	res := authz.Resource{BidAuthorType: bid.AuthorType}
+	switch bid.AuthorType {
+	case "User":
+		res.BidAuthorID = bid.AuthorID
+	case "Organization":
+		res.BidOrganizationID = bid.AuthorID
+	default:
+		return repository.ErrInvalidAuthorType
+	}
+
+	_, err := authorize(ctx, s.db, authz.BidCreate, res)
+	return err
+}
+
+// authorizeBid checks the permission on a bid in the context of its tender.
+func (s *BidService) authorizeBid(ctx context.Context, bid models.Bid, perm authz.Permission) error {
+	tender, err := s.db.GetTender(ctx, bid.TenderID)
+	if err != nil {
+		return err
+	}
+
+	_, err = authorize(ctx, s.db, perm, bidResource(tender, bid))
+	return err
+}
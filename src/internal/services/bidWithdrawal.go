@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"git.codenrock.com/avito/internal/auth"
+	"git.codenrock.com/avito/internal/authz"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/domain/models"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// WithdrawBid lets the bid author take a published bid back before it is decided.
+func (s *BidService) WithdrawBid(ctx context.Context, bidID string, withdrawal dto.WithdrawBidDTO, expectedVersion int) (dto.BidResponseDTO, error) {
+	const op = "services.bidService.WithdrawBid"
+
+	username, err := auth.Username(ctx)
+	if err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("bidID", bidID),
+	)
+
+	bidUUID, err := uuid.Parse(bidID)
+	if err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	reason := strings.TrimSpace(withdrawal.Reason)
+	if reason == "" || utf8.RuneCountInString(reason) > 1000 {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrInvalidWithdrawalReason)
+	}
+
+	var bidResponse dto.BidResponseDTO
+	err = s.db.WithinTx(ctx, func(ctx context.Context) error {
+		bid, err := s.db.LockBid(ctx, bidUUID)
+		if err != nil {
+			return err
+		}
+
+		if err = s.authorizeBid(ctx, bid, authz.BidEdit); err != nil {
+			return err
+		}
+
+		if err = checkExpectedVersion(bid.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		if bid.Status.IsDecided() {
+			log.Warn("Cannot withdraw decided bid", slog.String("status", bid.Status.String()))
+			return repository.ErrBidAlreadyDecided
+		}
+		if bid.Status != models.BidStatusPublished {
+			log.Warn("Only published bids can be withdrawn", slog.String("status", bid.Status.String()))
+			return repository.ErrIllegalBidTransition
+		}
+
+		log.Info("Withdrawing bid")
+
+		bidResponse, err = s.db.WithdrawBid(ctx, bidUUID, reason, username)
+		return err
+	})
+	if err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("Bid withdrawn")
+
+	return bidResponse, nil
+}
+
+// ResubmitBid publishes a withdrawn bid again while the tender still accepts bids.
+// The bid is revised beforehand with the usual edit endpoint.
+func (s *BidService) ResubmitBid(ctx context.Context, bidID string, expectedVersion int) (dto.BidResponseDTO, error) {
+	const op = "services.bidService.ResubmitBid"
+
+	username, err := auth.Username(ctx)
+	if err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("bidID", bidID),
+	)
+
+	bidUUID, err := uuid.Parse(bidID)
+	if err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var bidResponse dto.BidResponseDTO
+	err = s.db.WithinTx(ctx, func(ctx context.Context) error {
+		bid, err := s.db.LockBid(ctx, bidUUID)
+		if err != nil {
+			return err
+		}
+
+		if err = s.authorizeBid(ctx, bid, authz.BidEdit); err != nil {
+			return err
+		}
+
+		if err = checkExpectedVersion(bid.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		if bid.Status != models.BidStatusWithdrawn {
+			log.Warn("Only withdrawn bids can be resubmitted", slog.String("status", bid.Status.String()))
+			return repository.ErrIllegalBidTransition
+		}
+
+		tender, err := s.db.GetTender(ctx, bid.TenderID)
+		if err != nil {
+			return err
+		}
+
+		if tender.Status != models.TenderStatusPublished.String() {
+			log.Warn("Resubmission to unpublished tender", slog.String("tenderStatus", tender.Status))
+			return repository.ErrIllegalBidTransition
+		}
+		if err = submissionOpen(tender, time.Now()); err != nil {
+			log.Warn("Submission deadline has passed", slog.String("tenderID", tender.ID.String()))
+			return err
+		}
+
+		log.Info("Resubmitting bid")
+
+		bidResponse, err = s.db.ResubmitBid(ctx, bidUUID, username)
+		return err
+	})
+	if err != nil {
+		return dto.BidResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("Bid resubmitted")
+
+	return bidResponse, nil
+}
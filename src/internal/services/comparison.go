@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"git.codenrock.com/avito/internal/authz"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+	"log/slog"
+	"math/big"
+	"time"
+)
+
+const maxComparedBids = 20
+
+// CompareTenderBids puts the selected bids of a tender side by side: price, average
+// criteria scores with the weighted total, version, author organization, status and
+// number of reviews. It is authorized like GetTenderBids.
+func (s *BidService) CompareTenderBids(ctx context.Context, tenderID string, bidIDs []string) (dto.BidComparisonDTO, error) {
+	const op = "services.bidService.CompareTenderBids"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("tenderID", tenderID),
+	)
+
+	tenderUUID, err := uuid.Parse(tenderID)
+	if err != nil {
+		return dto.BidComparisonDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	ids, err := parseComparedIDs(bidIDs)
+	if err != nil {
+		return dto.BidComparisonDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tender, err := s.db.GetTender(ctx, tenderUUID)
+	if err != nil {
+		return dto.BidComparisonDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = authorize(ctx, s.db, authz.BidList, tenderResource(tender)); err != nil {
+		return dto.BidComparisonDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if bidsSealed(tender, time.Now()) {
+		return dto.BidComparisonDTO{}, fmt.Errorf("%s: %w", op, repository.ErrBidsSealed)
+	}
+
+	log.Info("Comparing bids", slog.Int("count", len(ids)))
+
+	bids, err := s.db.GetComparedBids(ctx, tenderUUID, ids)
+	if err != nil {
+		return dto.BidComparisonDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	criteria, err := s.db.GetTenderCriteria(ctx, tenderUUID)
+	if err != nil {
+		return dto.BidComparisonDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	board, err := s.db.GetBidsScoreboard(ctx, tenderUUID, ids)
+	if err != nil {
+		return dto.BidComparisonDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	scored := make(map[uuid.UUID]dto.BidRankingDTO, len(board))
+	for _, bid := range rankBids(board) {
+		scored[bid.BidID] = bid
+	}
+
+	for i := range bids {
+		bid := &bids[i]
+		bid.Scores = []dto.CriterionScoreDTO{}
+		bid.Total = "0.00"
+		if ranked, ok := scored[bid.BidID]; ok {
+			bid.Scores = ranked.Criteria
+			bid.Total = ranked.Total
+		}
+	}
+	indexPrices(bids)
+
+	return dto.BidComparisonDTO{
+		TenderID: tenderUUID,
+		Currency: tender.Currency,
+		Criteria: criteria,
+		Bids:     bids,
+	}, nil
+}
+
+func parseComparedIDs(raw []string) ([]uuid.UUID, error) {
+	seen := make(map[uuid.UUID]bool, len(raw))
+	ids := make([]uuid.UUID, 0, len(raw))
+	for _, value := range raw {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, repository.ErrInvalidComparison
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) == 0 || len(ids) > maxComparedBids {
+		return nil, repository.ErrInvalidComparison
+	}
+	return ids, nil
+}
+
+// indexPrices sets the price index of every priced bid relative to the lowest price.
+// Prices in different currencies are not comparable, so only the currency of the
+// cheapest bid is indexed.
+func indexPrices(bids []dto.ComparedBidDTO) {
+	prices := make([]*big.Rat, len(bids))
+	var lowest *big.Rat
+	var currency string
+	for i, bid := range bids {
+		if bid.Price == nil {
+			continue
+		}
+		price, ok := new(big.Rat).SetString(*bid.Price)
+		if !ok {
+			continue
+		}
+		prices[i] = price
+		if lowest == nil || price.Cmp(lowest) < 0 {
+			lowest, currency = price, bid.Currency
+		}
+	}
+
+	for i := range bids {
+		price := prices[i]
+		if price == nil || bids[i].Currency != currency {
+			continue
+		}
+		if price.Sign() == 0 {
+			bids[i].PriceIndex = hundred.FloatString(2)
+			continue
+		}
+		index := new(big.Rat).Quo(new(big.Rat).Mul(lowest, hundred), price)
+		bids[i].PriceIndex = index.FloatString(2)
+	}
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/repository"
+	"time"
+)
+
+// validateDeadlines checks the schedule a tender will have after a change: it must be
+// published before its submission deadline, and the decision deadline needs a
+// submission deadline and may not precede it.
+func validateDeadlines(publishAt, submission, decision *time.Time) error {
+	if publishAt != nil && submission != nil && !publishAt.Before(*submission) {
+		return repository.ErrInvalidDeadline
+	}
+	if decision == nil {
+		return nil
+	}
+	if submission == nil || decision.Before(*submission) {
+		return repository.ErrInvalidDeadline
+	}
+	return nil
+}
+
+// deadlinesInFuture rejects deadlines being set to a moment that has already passed.
+func deadlinesInFuture(now time.Time, deadlines ...*time.Time) error {
+	for _, deadline := range deadlines {
+		if deadline != nil && !deadline.After(now) {
+			return repository.ErrInvalidDeadline
+		}
+	}
+	return nil
+}
+
+// submissionOpen reports whether bids may still be created or edited on the tender.
+func submissionOpen(tender dto.TenderResponseDTO, now time.Time) error {
+	if tender.SubmissionDeadline != nil && !now.Before(*tender.SubmissionDeadline) {
+		return repository.ErrSubmissionClosed
+	}
+	return nil
+}
+
+// defaultDecisionPeriod is how long a tender that has a submission deadline but no
+// decision deadline stays open for decisions after the submission deadline.
+const defaultDecisionPeriod = 7 * 24 * time.Hour
+
+// decisionDeadline returns the moment decisions on the tender end and the scheduler
+// closes it: the decision deadline, or the default decision period after the
+// submission deadline. A tender with neither deadline is never due.
+func decisionDeadline(tender dto.TenderResponseDTO) *time.Time {
+	if tender.DecisionDeadline != nil {
+		return tender.DecisionDeadline
+	}
+	if tender.SubmissionDeadline != nil {
+		deadline := tender.SubmissionDeadline.Add(defaultDecisionPeriod)
+		return &deadline
+	}
+	return nil
+}
+
+// decisionOpen reports whether bids on the tender may still be decided.
+func decisionOpen(tender dto.TenderResponseDTO, now time.Time) error {
+	if deadline := decisionDeadline(tender); deadline != nil && !now.Before(*deadline) {
+		return repository.ErrDecisionClosed
+	}
+	return nil
+}
+
+// mergeDeadline returns the deadline a tender has after an edit that may leave it
+// unchanged or clear it.
+func mergeDeadline(current *time.Time, updated dto.OptionalTime) *time.Time {
+	if updated.Set {
+		return updated.Time
+	}
+	return current
+}
+
+// bidsSealed reports whether the contents of the bids on a sealed tender are still
+// hidden from the tender organization.
+func bidsSealed(tender dto.TenderResponseDTO, now time.Time) bool {
+	return tender.Sealed && (tender.SubmissionDeadline == nil || now.Before(*tender.SubmissionDeadline))
+}
+
+// sealBids strips everything but the metadata from bids listed while they are sealed.
+func sealBids(bids []dto.BidResponseDTO) []dto.BidResponseDTO {
+	sealed := make([]dto.BidResponseDTO, 0, len(bids))
+	for _, bid := range bids {
+		sealed = append(sealed, dto.BidResponseDTO{
+			ID:        bid.ID,
+			Status:    bid.Status,
+			Version:   bid.Version,
+			CreatedAt: bid.CreatedAt,
+			UpdatedAt: bid.UpdatedAt,
+			Sealed:    true,
+		})
+	}
+	return sealed
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestDecisionOpen(t *testing.T) {
+	now := time.Date(2024, 9, 10, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+
+	tests := []struct {
+		name    string
+		tender  dto.TenderResponseDTO
+		wantErr error
+	}{
+		{"no deadlines", dto.TenderResponseDTO{}, nil},
+		{"before the decision deadline", dto.TenderResponseDTO{SubmissionDeadline: at(-time.Hour), DecisionDeadline: at(time.Hour)}, nil},
+		{"at the decision deadline", dto.TenderResponseDTO{SubmissionDeadline: at(-time.Hour), DecisionDeadline: at(0)}, repository.ErrDecisionClosed},
+		{"only a submission deadline, within the decision period",
+			dto.TenderResponseDTO{SubmissionDeadline: at(-defaultDecisionPeriod + time.Second)}, nil},
+		{"only a submission deadline, after the decision period",
+			dto.TenderResponseDTO{SubmissionDeadline: at(-defaultDecisionPeriod)}, repository.ErrDecisionClosed},
+		{"decision deadline beyond the default period",
+			dto.TenderResponseDTO{SubmissionDeadline: at(-2 * defaultDecisionPeriod), DecisionDeadline: at(time.Hour)}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := decisionOpen(tt.tender, now); !errors.Is(err, tt.wantErr) {
+				t.Errorf("decisionOpen() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// expiringStorage records the scheduler's calls to CloseExpiredTenders.
+type expiringStorage struct {
+	Storage
+
+	batches []int
+	periods []time.Duration
+}
+
+func (f *expiringStorage) CloseExpiredTenders(_ context.Context, actor string, decisionPeriod time.Duration, limit int) ([]uuid.UUID, error) {
+	f.periods = append(f.periods, decisionPeriod)
+
+	n := 0
+	if len(f.batches) > 0 {
+		n, f.batches = f.batches[0], f.batches[1:]
+	}
+	ids := make([]uuid.UUID, n)
+	for i := range ids {
+		ids[i] = uuid.New()
+	}
+	return ids, nil
+}
+
+func TestCloseExpiredTenders(t *testing.T) {
+	db := &expiringStorage{batches: []int{scheduledTendersBatch, 3}}
+	s := NewTenderService(slog.New(slog.NewTextHandler(io.Discard, nil)), db)
+
+	if err := s.CloseExpiredTenders(context.Background()); err != nil {
+		t.Fatalf("CloseExpiredTenders() error = %v", err)
+	}
+
+	if len(db.periods) != 2 {
+		t.Fatalf("storage called %d times, want 2", len(db.periods))
+	}
+	for _, period := range db.periods {
+		if period != defaultDecisionPeriod {
+			t.Errorf("decision period = %v, want %v", period, defaultDecisionPeriod)
+		}
+	}
+}
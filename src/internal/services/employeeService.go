@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"git.codenrock.com/avito/internal/authz"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/domain/models"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"log/slog"
+)
+
+type EmployeeStorage interface {
+	SubjectStorage
+	CreateEmployee(ctx context.Context, employee dto.CreateEmployeeDTO, passwordHash string) (models.User, error)
+	GetEmployees(ctx context.Context, limit, offset int) ([]models.User, error)
+	GetEmployee(ctx context.Context, id uuid.UUID) (models.User, error)
+	UpdateEmployee(ctx context.Context, id uuid.UUID, data dto.UpdateEmployeeDTO, passwordHash string) (models.User, error)
+	DeleteEmployee(ctx context.Context, id uuid.UUID) error
+}
+
+type EmployeeService struct {
+	log *slog.Logger
+	db  EmployeeStorage
+}
+
+var (
+	ErrInvalidPassword = fmt.Errorf("password must be at most 72 bytes")
+)
+
+func NewEmployeeService(log *slog.Logger, db EmployeeStorage) *EmployeeService {
+	return &EmployeeService{
+		log: log,
+		db:  db,
+	}
+}
+
+func (s *EmployeeService) CreateEmployee(ctx context.Context, employee dto.CreateEmployeeDTO) (models.User, error) {
+	const op = "services.employeeService.CreateEmployee"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("username", employee.Username),
+	)
+
+	if _, err := authorize(ctx, s.db, authz.EmployeeManage, authz.Resource{}); err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	passwordHash, err := hashPassword(employee.Password)
+	if err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	user, err := s.db.CreateEmployee(ctx, employee, passwordHash)
+	if err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("Employee created", slog.String("id", user.ID.String()))
+
+	return user, nil
+}
+
+func (s *EmployeeService) GetEmployees(ctx context.Context, limit, offset int) ([]models.User, error) {
+	const op = "services.employeeService.GetEmployees"
+
+	if _, err := authorize(ctx, s.db, authz.EmployeeView, authz.Resource{}); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	users, err := s.db.GetEmployees(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, nil
+}
+
+func (s *EmployeeService) GetEmployee(ctx context.Context, employeeID string) (models.User, error) {
+	const op = "services.employeeService.GetEmployee"
+
+	id, err := uuid.Parse(employeeID)
+	if err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, repository.ErrUserNotFound)
+	}
+
+	if _, err = authorize(ctx, s.db, authz.EmployeeView, authz.Resource{EmployeeID: id}); err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	user, err := s.db.GetEmployee(ctx, id)
+	if err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, nil
+}
+
+// UpdateEmployee lets employees edit their own profile and password. Only platform
+// admins may grant or revoke the admin flag.
+func (s *EmployeeService) UpdateEmployee(ctx context.Context, employeeID string, data dto.UpdateEmployeeDTO) (models.User, error) {
+	const op = "services.employeeService.UpdateEmployee"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("employeeID", employeeID),
+	)
+
+	id, err := uuid.Parse(employeeID)
+	if err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, repository.ErrUserNotFound)
+	}
+
+	perm := authz.EmployeeEdit
+	if data.IsAdmin != nil {
+		perm = authz.EmployeeManage
+	}
+	if _, err = authorize(ctx, s.db, perm, authz.Resource{EmployeeID: id}); err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	passwordHash, err := hashPassword(data.Password)
+	if err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	user, err := s.db.UpdateEmployee(ctx, id, data, passwordHash)
+	if err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("Employee updated")
+
+	return user, nil
+}
+
+func (s *EmployeeService) DeleteEmployee(ctx context.Context, employeeID string) error {
+	const op = "services.employeeService.DeleteEmployee"
+
+	id, err := uuid.Parse(employeeID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, repository.ErrUserNotFound)
+	}
+
+	if _, err = authorize(ctx, s.db, authz.EmployeeManage, authz.Resource{EmployeeID: id}); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.db.DeleteEmployee(ctx, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("Employee deleted", slog.String("op", op), slog.String("employeeID", employeeID))
+
+	return nil
+}
+
+// hashPassword returns the bcrypt hash of the password, or an empty string when no
+// password is given.
+func hashPassword(password string) (string, error) {
+	if password == "" {
+		return "", nil
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+			return "", ErrInvalidPassword
+		}
+		return "", err
+	}
+
+	return string(hash), nil
+}
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"git.codenrock.com/avito/internal/authz"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/domain/models"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+	"log/slog"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var scorePattern = regexp.MustCompile(`^\d{1,3}(\.\d{1,2})?$`)
+
+var hundred = big.NewRat(100, 1)
+
+func (s *TenderService) GetTenderCriteria(ctx context.Context, tenderID string) ([]dto.TenderCriterionDTO, error) {
+	const op = "services.tenderService.GetTenderCriteria"
+
+	tenderUUID, err := uuid.Parse(tenderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tender, err := s.db.GetTender(ctx, tenderUUID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = authorize(ctx, s.db, authz.TenderView, tenderResource(tender)); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	criteria, err := s.db.GetTenderCriteria(ctx, tenderUUID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return criteria, nil
+}
+
+// ReplaceTenderCriteria sets the evaluation criteria of a tender. Weights are
+// percentages and must add up to exactly 100.
+func (s *TenderService) ReplaceTenderCriteria(ctx context.Context, tenderID string, criteria []dto.CriterionDTO) ([]dto.TenderCriterionDTO, error) {
+	const op = "services.tenderService.ReplaceTenderCriteria"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("tenderID", tenderID),
+	)
+
+	tenderUUID, err := uuid.Parse(tenderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = validateCriteria(criteria); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var replaced []dto.TenderCriterionDTO
+	err = s.db.WithinTx(ctx, func(ctx context.Context) error {
+		tender, err := s.db.LockTender(ctx, tenderUUID)
+		if err != nil {
+			return err
+		}
+
+		if _, err = authorize(ctx, s.db, authz.TenderEdit, tenderResource(tender)); err != nil {
+			return err
+		}
+
+		log.Info("Replacing tender criteria", slog.Int("count", len(criteria)))
+
+		replaced, err = s.db.ReplaceTenderCriteria(ctx, tenderUUID, criteria)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return replaced, nil
+}
+
+// GetTenderRanking ranks the published and approved bids of a tender by the weighted
+// average of the scores given by the responsibles. A criterion nobody has scored yet
+// counts as zero.
+func (s *TenderService) GetTenderRanking(ctx context.Context, tenderID string) ([]dto.BidRankingDTO, error) {
+	const op = "services.tenderService.GetTenderRanking"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("tenderID", tenderID),
+	)
+
+	tenderUUID, err := uuid.Parse(tenderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tender, err := s.db.GetTender(ctx, tenderUUID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = authorize(ctx, s.db, authz.BidList, tenderResource(tender)); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if bidsSealed(tender, time.Now()) {
+		return nil, fmt.Errorf("%s: %w", op, repository.ErrBidsSealed)
+	}
+
+	log.Info("Ranking tender bids")
+
+	board, err := s.db.GetTenderScoreboard(ctx, tenderUUID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rankBids(board), nil
+}
+
+func (s *BidService) GetBidScores(ctx context.Context, bidID string) ([]dto.BidScoreDTO, error) {
+	const op = "services.bidService.GetBidScores"
+
+	bidUUID, err := uuid.Parse(bidID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	bid, err := s.db.GetBid(ctx, bidUUID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tender, err := s.db.GetTender(ctx, bid.TenderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = authorize(ctx, s.db, authz.BidList, bidResource(tender, bid)); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	scores, err := s.db.GetBidScores(ctx, bidUUID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return scores, nil
+}
+
+// ScoreBid records the caller's scores for a published bid of a published tender.
+func (s *BidService) ScoreBid(ctx context.Context, bidID string, scores []dto.ScoreDTO) ([]dto.BidScoreDTO, error) {
+	const op = "services.bidService.ScoreBid"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("bidID", bidID),
+	)
+
+	bidUUID, err := uuid.Parse(bidID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = validateScores(scores); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var saved []dto.BidScoreDTO
+	err = s.db.WithinTx(ctx, func(ctx context.Context) error {
+		bid, err := s.db.LockBid(ctx, bidUUID)
+		if err != nil {
+			return err
+		}
+
+		tender, err := s.db.GetTender(ctx, bid.TenderID)
+		if err != nil {
+			return err
+		}
+
+		scorer, err := authorize(ctx, s.db, authz.BidScore, bidResource(tender, bid))
+		if err != nil {
+			return err
+		}
+
+		if bidsSealed(tender, time.Now()) {
+			log.Warn("Cannot score sealed bid")
+			return repository.ErrBidsSealed
+		}
+
+		if bid.Status != models.BidStatusPublished || tender.Status != models.TenderStatusPublished.String() {
+			log.Warn("Cannot score bid", slog.String("status", bid.Status.String()), slog.String("tenderStatus", tender.Status))
+			return repository.ErrBidNotScorable
+		}
+
+		log.Info("Scoring bid", slog.Int("count", len(scores)))
+
+		saved, err = s.db.SaveBidScores(ctx, bidUUID, tender.ID, scorer.UserID, scores)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return saved, nil
+}
+
+func validateCriteria(criteria []dto.CriterionDTO) error {
+	if len(criteria) == 0 {
+		return repository.ErrInvalidCriteria
+	}
+
+	total := new(big.Rat)
+	for i := range criteria {
+		criterion := &criteria[i]
+		if strings.TrimSpace(criterion.Name) == "" || utf8.RuneCountInString(criterion.Name) > 100 {
+			return repository.ErrInvalidCriteria
+		}
+		weight, err := parseDecimal(criterion.Weight, scorePattern)
+		if err != nil || weight.Sign() == 0 {
+			return repository.ErrInvalidCriteria
+		}
+		criterion.Weight = weight.FloatString(2)
+		total.Add(total, weight)
+	}
+
+	if total.Cmp(hundred) != 0 {
+		return repository.ErrInvalidCriteria
+	}
+	return nil
+}
+
+func validateScores(scores []dto.ScoreDTO) error {
+	if len(scores) == 0 {
+		return repository.ErrInvalidScore
+	}
+
+	seen := make(map[uuid.UUID]bool, len(scores))
+	for i := range scores {
+		score := &scores[i]
+		if seen[score.CriterionID] || utf8.RuneCountInString(score.Comment) > 500 {
+			return repository.ErrInvalidScore
+		}
+		seen[score.CriterionID] = true
+
+		value, err := parseDecimal(score.Score, scorePattern)
+		if err != nil || value.Cmp(hundred) > 0 {
+			return repository.ErrInvalidScore
+		}
+		score.Score = value.FloatString(2)
+	}
+	return nil
+}
+
+// rankBids computes the weighted total of every bid and orders the bids by it. Equal
+// totals share a rank.
+func rankBids(board []dto.BidRankingDTO) []dto.BidRankingDTO {
+	totals := make([]*big.Rat, len(board))
+	for i, bid := range board {
+		total := new(big.Rat)
+		for _, criterion := range bid.Criteria {
+			if criterion.Average == "" {
+				continue
+			}
+			average, ok1 := new(big.Rat).SetString(criterion.Average)
+			weight, ok2 := new(big.Rat).SetString(criterion.Weight)
+			if ok1 && ok2 {
+				total.Add(total, new(big.Rat).Mul(average, weight))
+			}
+		}
+		totals[i] = total.Quo(total, hundred)
+	}
+
+	order := make([]int, len(board))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return totals[order[a]].Cmp(totals[order[b]]) > 0
+	})
+
+	ranking := make([]dto.BidRankingDTO, 0, len(board))
+	for position, i := range order {
+		bid := board[i]
+		bid.Total = totals[i].FloatString(2)
+		bid.Rank = position + 1
+		if position > 0 && totals[i].Cmp(totals[order[position-1]]) == 0 {
+			bid.Rank = ranking[position-1].Rank
+		}
+		ranking = append(ranking, bid)
+	}
+	return ranking
+}
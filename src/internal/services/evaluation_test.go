@@ -0,0 +1,70 @@
+package services
+
+import (
+	"git.codenrock.com/avito/internal/domain/dto"
+	"testing"
+)
+
+func TestRankBids(t *testing.T) {
+	scored := func(name string, averages ...string) dto.BidRankingDTO {
+		weights := []string{"60.00", "40.00"}
+		bid := dto.BidRankingDTO{BidName: name}
+		for i, average := range averages {
+			bid.Criteria = append(bid.Criteria, dto.CriterionScoreDTO{Weight: weights[i], Average: average})
+		}
+		return bid
+	}
+
+	type ranked struct {
+		name  string
+		rank  int
+		total string
+	}
+
+	tests := []struct {
+		name  string
+		board []dto.BidRankingDTO
+		want  []ranked
+	}{
+		{
+			name:  "empty",
+			board: nil,
+			want:  []ranked{},
+		},
+		{
+			name:  "weighted totals",
+			board: []dto.BidRankingDTO{scored("a", "50", "100"), scored("b", "100", "50"), scored("c", "10", "10")},
+			want:  []ranked{{"b", 1, "80.00"}, {"a", 2, "70.00"}, {"c", 3, "10.00"}},
+		},
+		{
+			name:  "ties share a rank and keep their order",
+			board: []dto.BidRankingDTO{scored("a", "50", "50"), scored("b", "90", "90"), scored("c", "50", "50"), scored("d", "0", "0")},
+			want:  []ranked{{"b", 1, "90.00"}, {"a", 2, "50.00"}, {"c", 2, "50.00"}, {"d", 4, "0.00"}},
+		},
+		{
+			name:  "unscored criteria count as zero",
+			board: []dto.BidRankingDTO{scored("a", "", "100"), scored("b", "100", "")},
+			want:  []ranked{{"b", 1, "60.00"}, {"a", 2, "40.00"}},
+		},
+		{
+			name:  "fractional totals are rounded",
+			board: []dto.BidRankingDTO{scored("a", "33.33", "66.67")},
+			want:  []ranked{{"a", 1, "46.67"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rankBids(tt.board)
+			if len(got) != len(tt.want) {
+				t.Fatalf("rankBids() returned %d bids, want %d", len(got), len(tt.want))
+			}
+			for i, want := range tt.want {
+				if got[i].BidName != want.name || got[i].Rank != want.rank || got[i].Total != want.total {
+					t.Errorf("position %d = %s rank %d total %s, want %s rank %d total %s",
+						i, got[i].BidName, got[i].Rank, got[i].Total, want.name, want.rank, want.total)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/repository"
+	"math/big"
+	"regexp"
+	"unicode/utf8"
+)
+
+var (
+	amountPattern   = regexp.MustCompile(`^\d{1,16}(\.\d{1,2})?$`)
+	quantityPattern = regexp.MustCompile(`^\d{1,15}(\.\d{1,3})?$`)
+	currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+)
+
+// parseDecimal parses a non-negative decimal string that fits the NUMERIC column it
+// is stored in. Floats are never involved, so amounts stay exact.
+func parseDecimal(value string, pattern *regexp.Regexp) (*big.Rat, error) {
+	if !pattern.MatchString(value) {
+		return nil, repository.ErrInvalidAmount
+	}
+	r, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return nil, repository.ErrInvalidAmount
+	}
+	return r, nil
+}
+
+// normalizeAmount validates an optional amount and rewrites it with two decimals.
+func normalizeAmount(value *string) (*big.Rat, error) {
+	if value == nil {
+		return nil, nil
+	}
+	r, err := parseDecimal(*value, amountPattern)
+	if err != nil {
+		return nil, err
+	}
+	*value = r.FloatString(2)
+	return r, nil
+}
+
+func validateCurrency(currency string) error {
+	if !currencyPattern.MatchString(currency) {
+		return repository.ErrInvalidCurrency
+	}
+	return nil
+}
+
+// validateBudget checks the budget a tender will have after a change: amounts are
+// exact decimals with a currency, and the minimum does not exceed the maximum.
+func validateBudget(budgetMin, budgetMax *string, currency string) error {
+	minimum, err := normalizeAmount(budgetMin)
+	if err != nil {
+		return err
+	}
+	maximum, err := normalizeAmount(budgetMax)
+	if err != nil {
+		return err
+	}
+
+	if currency != "" {
+		if err = validateCurrency(currency); err != nil {
+			return err
+		}
+	} else if minimum != nil || maximum != nil {
+		return repository.ErrInvalidCurrency
+	}
+
+	if minimum != nil && maximum != nil && minimum.Cmp(maximum) > 0 {
+		return repository.ErrInvalidBudget
+	}
+	return nil
+}
+
+// roundAmount rounds to two decimals with halves away from zero, as ROUND(x, 2) does
+// for the line amounts the database reports.
+func roundAmount(r *big.Rat) *big.Rat {
+	rounded, _ := new(big.Rat).SetString(r.FloatString(2))
+	return rounded
+}
+
+// priceBid validates the price and line items of a new bid against the tender. The
+// currency defaults to the tender's, the price to the total of the rounded line
+// amounts, and a tender that caps bids at its budget rejects prices above the maximum.
+func priceBid(bid *dto.BidDTO, tender dto.TenderResponseDTO) error {
+	total := new(big.Rat)
+	for i := range bid.Items {
+		item := &bid.Items[i]
+		if item.Name == "" || utf8.RuneCountInString(item.Name) > 100 {
+			return repository.ErrInvalidAmount
+		}
+		quantity, err := parseDecimal(item.Quantity, quantityPattern)
+		if err != nil || quantity.Sign() == 0 {
+			return repository.ErrInvalidAmount
+		}
+		unitPrice, err := parseDecimal(item.UnitPrice, amountPattern)
+		if err != nil {
+			return err
+		}
+		item.UnitPrice = unitPrice.FloatString(2)
+		item.Amount = ""
+		total.Add(total, roundAmount(new(big.Rat).Mul(quantity, unitPrice)))
+	}
+
+	if bid.Price == nil && len(bid.Items) > 0 {
+		price := total.FloatString(2)
+		bid.Price = &price
+	}
+
+	price, err := normalizeAmount(bid.Price)
+	if err != nil {
+		return err
+	}
+	if price == nil {
+		if bid.Currency != "" {
+			return repository.ErrInvalidAmount
+		}
+		return nil
+	}
+	if len(bid.Items) > 0 && *bid.Price != total.FloatString(2) {
+		return repository.ErrInvalidAmount
+	}
+
+	if bid.Currency == "" {
+		bid.Currency = tender.Currency
+	}
+	if err = validateCurrency(bid.Currency); err != nil {
+		return err
+	}
+	if tender.Currency != "" && bid.Currency != tender.Currency {
+		return repository.ErrCurrencyMismatch
+	}
+
+	if tender.CapAtBudget && tender.BudgetMax != nil {
+		maximum, ok := new(big.Rat).SetString(*tender.BudgetMax)
+		if ok && price.Cmp(maximum) > 0 {
+			return repository.ErrPriceAboveBudget
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"errors"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/repository"
+	"strings"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"0", "0.00", false},
+		{"100", "100.00", false},
+		{"100.5", "100.50", false},
+		{"100.55", "100.55", false},
+		{"9999999999999999.99", "9999999999999999.99", false},
+		{"100.555", "", true},
+		{"99999999999999999", "", true},
+		{"-1", "", true},
+		{"+1", "", true},
+		{"1e3", "", true},
+		{"1,5", "", true},
+		{".5", "", true},
+		{"5.", "", true},
+		{" 5", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseDecimal(tt.value, amountPattern)
+			if tt.wantErr {
+				if !errors.Is(err, repository.ErrInvalidAmount) {
+					t.Errorf("parseDecimal(%q) error = %v, want %v", tt.value, err, repository.ErrInvalidAmount)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseDecimal(%q) error = %v", tt.value, err)
+			}
+			if got.FloatString(2) != tt.want {
+				t.Errorf("parseDecimal(%q) = %s, want %s", tt.value, got.FloatString(2), tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateBudget(t *testing.T) {
+	amount := func(s string) *string { return &s }
+
+	tests := []struct {
+		name                 string
+		budgetMin, budgetMax *string
+		currency             string
+		wantMin, wantMax     string
+		wantErr              error
+	}{
+		{name: "no budget"},
+		{name: "currency only", currency: "RUB"},
+		{name: "normalized", budgetMin: amount("10"), budgetMax: amount("20.5"), currency: "RUB", wantMin: "10.00", wantMax: "20.50"},
+		{name: "equal bounds", budgetMin: amount("10"), budgetMax: amount("10.00"), currency: "RUB", wantMin: "10.00", wantMax: "10.00"},
+		{name: "minimum above maximum", budgetMin: amount("20"), budgetMax: amount("10"), currency: "RUB", wantErr: repository.ErrInvalidBudget},
+		{name: "amount without currency", budgetMax: amount("10"), wantErr: repository.ErrInvalidCurrency},
+		{name: "lowercase currency", budgetMax: amount("10"), currency: "rub", wantErr: repository.ErrInvalidCurrency},
+		{name: "invalid amount", budgetMin: amount("ten"), currency: "RUB", wantErr: repository.ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBudget(tt.budgetMin, tt.budgetMax, tt.currency)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("validateBudget() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if tt.budgetMin != nil && *tt.budgetMin != tt.wantMin {
+				t.Errorf("budgetMin = %s, want %s", *tt.budgetMin, tt.wantMin)
+			}
+			if tt.budgetMax != nil && *tt.budgetMax != tt.wantMax {
+				t.Errorf("budgetMax = %s, want %s", *tt.budgetMax, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestPriceBid(t *testing.T) {
+	amount := func(s string) *string { return &s }
+	tender := dto.TenderResponseDTO{Currency: "RUB", BudgetMax: amount("1000.00"), CapAtBudget: true}
+
+	tests := []struct {
+		name         string
+		bid          dto.BidDTO
+		tender       dto.TenderResponseDTO
+		wantPrice    string
+		wantCurrency string
+		wantErr      error
+	}{
+		{name: "no price", bid: dto.BidDTO{}, tender: tender},
+		{name: "currency defaults to tender", bid: dto.BidDTO{Price: amount("500")}, tender: tender, wantPrice: "500.00", wantCurrency: "RUB"},
+		{name: "price from items", bid: dto.BidDTO{Items: []dto.BidItemDTO{
+			{Name: "Pipe", Quantity: "2.5", UnitPrice: "100"},
+			{Name: "Valve", Quantity: "1", UnitPrice: "0.99"},
+		}}, tender: tender, wantPrice: "250.99", wantCurrency: "RUB"},
+		{name: "price from rounded line amounts", bid: dto.BidDTO{Items: []dto.BidItemDTO{
+			{Name: "Bolt", Quantity: "0.005", UnitPrice: "1"},
+			{Name: "Nut", Quantity: "0.005", UnitPrice: "1"},
+		}}, tender: tender, wantPrice: "0.02", wantCurrency: "RUB"},
+		{name: "price matches items", bid: dto.BidDTO{Price: amount("200.00"), Items: []dto.BidItemDTO{
+			{Name: "Pipe", Quantity: "2", UnitPrice: "100"},
+		}}, tender: tender, wantPrice: "200.00", wantCurrency: "RUB"},
+		{name: "price differs from items", bid: dto.BidDTO{Price: amount("150"), Items: []dto.BidItemDTO{
+			{Name: "Pipe", Quantity: "2", UnitPrice: "100"},
+		}}, tender: tender, wantErr: repository.ErrInvalidAmount},
+		{name: "zero quantity", bid: dto.BidDTO{Items: []dto.BidItemDTO{{Name: "Pipe", Quantity: "0", UnitPrice: "1"}}},
+			tender: tender, wantErr: repository.ErrInvalidAmount},
+		{name: "unnamed item", bid: dto.BidDTO{Items: []dto.BidItemDTO{{Quantity: "1", UnitPrice: "1"}}},
+			tender: tender, wantErr: repository.ErrInvalidAmount},
+		{name: "item name too long", bid: dto.BidDTO{Items: []dto.BidItemDTO{{Name: strings.Repeat("т", 101), Quantity: "1", UnitPrice: "1"}}},
+			tender: tender, wantErr: repository.ErrInvalidAmount},
+		{name: "item name of 100 letters", bid: dto.BidDTO{Items: []dto.BidItemDTO{{Name: strings.Repeat("т", 100), Quantity: "1", UnitPrice: "1"}}},
+			tender: tender, wantPrice: "1.00", wantCurrency: "RUB"},
+		{name: "currency without price", bid: dto.BidDTO{Currency: "RUB"}, tender: tender, wantErr: repository.ErrInvalidAmount},
+		{name: "other currency", bid: dto.BidDTO{Price: amount("10"), Currency: "USD"}, tender: tender, wantErr: repository.ErrCurrencyMismatch},
+		{name: "above capped budget", bid: dto.BidDTO{Price: amount("1000.01")}, tender: tender, wantErr: repository.ErrPriceAboveBudget},
+		{name: "above uncapped budget", bid: dto.BidDTO{Price: amount("2000")},
+			tender: dto.TenderResponseDTO{Currency: "RUB", BudgetMax: amount("1000.00")}, wantPrice: "2000.00", wantCurrency: "RUB"},
+		{name: "tender without currency", bid: dto.BidDTO{Price: amount("10")}, tender: dto.TenderResponseDTO{},
+			wantErr: repository.ErrInvalidCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bid := tt.bid
+			err := priceBid(&bid, tt.tender)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("priceBid() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if formatPrice(bid.Price) != tt.wantPrice || bid.Currency != tt.wantCurrency {
+				t.Errorf("priceBid() = %s %s, want %s %s", formatPrice(bid.Price), bid.Currency, tt.wantPrice, tt.wantCurrency)
+			}
+		})
+	}
+}
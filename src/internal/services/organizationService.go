@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"git.codenrock.com/avito/internal/authz"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/domain/models"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+	"log/slog"
+)
+
+type OrganizationStorage interface {
+	SubjectStorage
+	CreateOrganization(ctx context.Context, org dto.OrganizationDTO) (models.Organization, error)
+	GetOrganizations(ctx context.Context, limit, offset int) ([]models.Organization, error)
+	GetOrganization(ctx context.Context, id uuid.UUID) (models.Organization, error)
+	UpdateOrganization(ctx context.Context, id uuid.UUID, data dto.UpdateOrganizationDTO) (models.Organization, error)
+	DeleteOrganization(ctx context.Context, id uuid.UUID) error
+	GetOrganizationResponsibles(ctx context.Context, organizationID uuid.UUID) ([]models.User, error)
+	AddOrganizationResponsible(ctx context.Context, organizationID, userID uuid.UUID) error
+	RemoveOrganizationResponsible(ctx context.Context, organizationID, userID uuid.UUID) error
+	GetEmployee(ctx context.Context, id uuid.UUID) (models.User, error)
+	GetEmployeeByUsername(ctx context.Context, username string) (models.User, error)
+}
+
+type OrganizationService struct {
+	log *slog.Logger
+	db  OrganizationStorage
+}
+
+func NewOrganizationService(log *slog.Logger, db OrganizationStorage) *OrganizationService {
+	return &OrganizationService{
+		log: log,
+		db:  db,
+	}
+}
+
+func (s *OrganizationService) CreateOrganization(ctx context.Context, org dto.OrganizationDTO) (models.Organization, error) {
+	const op = "services.organizationService.CreateOrganization"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("name", org.Name),
+	)
+
+	if _, err := authorize(ctx, s.db, authz.OrganizationManage, authz.Resource{}); err != nil {
+		return models.Organization{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !org.Type.IsValid() {
+		return models.Organization{}, fmt.Errorf("%s: %w", op, repository.ErrInvalidOrganizationType)
+	}
+
+	created, err := s.db.CreateOrganization(ctx, org)
+	if err != nil {
+		return models.Organization{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("Organization created", slog.String("id", created.ID.String()))
+
+	return created, nil
+}
+
+func (s *OrganizationService) GetOrganizations(ctx context.Context, limit, offset int) ([]models.Organization, error) {
+	const op = "services.organizationService.GetOrganizations"
+
+	if _, err := authorize(ctx, s.db, authz.OrganizationView, authz.Resource{}); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	organizations, err := s.db.GetOrganizations(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return organizations, nil
+}
+
+func (s *OrganizationService) GetOrganization(ctx context.Context, organizationID string) (models.Organization, error) {
+	const op = "services.organizationService.GetOrganization"
+
+	id, err := s.authorizeOrganization(ctx, organizationID, authz.OrganizationView)
+	if err != nil {
+		return models.Organization{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	org, err := s.db.GetOrganization(ctx, id)
+	if err != nil {
+		return models.Organization{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return org, nil
+}
+
+func (s *OrganizationService) UpdateOrganization(ctx context.Context, organizationID string, data dto.UpdateOrganizationDTO) (models.Organization, error) {
+	const op = "services.organizationService.UpdateOrganization"
+
+	id, err := s.authorizeOrganization(ctx, organizationID, authz.OrganizationEdit)
+	if err != nil {
+		return models.Organization{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if data.Type != nil && !data.Type.IsValid() {
+		return models.Organization{}, fmt.Errorf("%s: %w", op, repository.ErrInvalidOrganizationType)
+	}
+
+	org, err := s.db.UpdateOrganization(ctx, id, data)
+	if err != nil {
+		return models.Organization{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("Organization updated", slog.String("op", op), slog.String("organizationID", organizationID))
+
+	return org, nil
+}
+
+func (s *OrganizationService) DeleteOrganization(ctx context.Context, organizationID string) error {
+	const op = "services.organizationService.DeleteOrganization"
+
+	id, err := s.authorizeOrganization(ctx, organizationID, authz.OrganizationManage)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.db.DeleteOrganization(ctx, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("Organization deleted", slog.String("op", op), slog.String("organizationID", organizationID))
+
+	return nil
+}
+
+func (s *OrganizationService) GetResponsibles(ctx context.Context, organizationID string) ([]models.User, error) {
+	const op = "services.organizationService.GetResponsibles"
+
+	id, err := s.authorizeOrganization(ctx, organizationID, authz.OrganizationView)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = s.db.GetOrganization(ctx, id); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	users, err := s.db.GetOrganizationResponsibles(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, nil
+}
+
+// AddResponsible makes the employee named by id or username a responsible of the
+// organization.
+func (s *OrganizationService) AddResponsible(ctx context.Context, organizationID string, data dto.AddResponsibleDTO) (models.User, error) {
+	const op = "services.organizationService.AddResponsible"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("organizationID", organizationID),
+	)
+
+	id, err := s.authorizeOrganization(ctx, organizationID, authz.OrganizationMembersManage)
+	if err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = s.db.GetOrganization(ctx, id); err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var user models.User
+	switch {
+	case data.UserID != uuid.Nil:
+		user, err = s.db.GetEmployee(ctx, data.UserID)
+	case data.Username != "":
+		user, err = s.db.GetEmployeeByUsername(ctx, data.Username)
+	default:
+		err = repository.ErrUsernameFieldEmpty
+	}
+	if err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.db.AddOrganizationResponsible(ctx, id, user.ID); err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("Responsible added", slog.String("userID", user.ID.String()))
+
+	return user, nil
+}
+
+func (s *OrganizationService) RemoveResponsible(ctx context.Context, organizationID, userID string) error {
+	const op = "services.organizationService.RemoveResponsible"
+
+	id, err := s.authorizeOrganization(ctx, organizationID, authz.OrganizationMembersManage)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, repository.ErrResponsibleNotFound)
+	}
+
+	if err = s.db.RemoveOrganizationResponsible(ctx, id, userUUID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("Responsible removed", slog.String("op", op), slog.String("organizationID", organizationID), slog.String("userID", userID))
+
+	return nil
+}
+
+func (s *OrganizationService) authorizeOrganization(ctx context.Context, organizationID string, perm authz.Permission) (uuid.UUID, error) {
+	id, err := uuid.Parse(organizationID)
+	if err != nil {
+		return uuid.Nil, repository.ErrOrganizationNotFound
+	}
+
+	if _, err = authorize(ctx, s.db, perm, authz.Resource{OrganizationID: id}); err != nil {
+		return uuid.Nil, err
+	}
+
+	return id, nil
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"git.codenrock.com/avito/internal/auth"
+	"git.codenrock.com/avito/internal/authz"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/domain/models"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// sealedStorage serves one sealed tender with one published bid. Methods the flow
+// does not use are left to the embedded nil interface and panic if called.
+type sealedStorage struct {
+	BidStorage
+
+	tender    dto.TenderResponseDTO
+	bid       models.Bid
+	subjects  map[string]authz.Subject
+	decisions []string
+}
+
+func (f *sealedStorage) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (f *sealedStorage) GetSubject(_ context.Context, username string) (authz.Subject, error) {
+	subject, ok := f.subjects[username]
+	if !ok {
+		return authz.Subject{}, repository.ErrUserNotFound
+	}
+	return subject, nil
+}
+
+func (f *sealedStorage) GetTender(context.Context, uuid.UUID) (dto.TenderResponseDTO, error) {
+	return f.tender, nil
+}
+
+func (f *sealedStorage) LockTender(context.Context, uuid.UUID) (dto.TenderResponseDTO, error) {
+	return f.tender, nil
+}
+
+func (f *sealedStorage) GetBid(context.Context, uuid.UUID) (models.Bid, error) {
+	return f.bid, nil
+}
+
+func (f *sealedStorage) LockBid(context.Context, uuid.UUID) (models.Bid, error) {
+	return f.bid, nil
+}
+
+func (f *sealedStorage) GetTenderBids(context.Context, uuid.UUID, dto.ListQueryDTO) (dto.PageDTO[dto.BidResponseDTO], error) {
+	price := "100.00"
+	return dto.PageDTO[dto.BidResponseDTO]{Items: []dto.BidResponseDTO{{
+		ID:      f.bid.ID,
+		Name:    f.bid.Name,
+		Status:  f.bid.Status.String(),
+		Version: f.bid.Version,
+		Price:   &price,
+	}}}, nil
+}
+
+func (f *sealedStorage) LockTenderAuction(context.Context, uuid.UUID) (dto.TenderAuctionDTO, error) {
+	return dto.TenderAuctionDTO{}, repository.ErrAuctionNotFound
+}
+
+func (f *sealedStorage) SubmitDecision(_ context.Context, bidID uuid.UUID, decision, _ string) (dto.BidResponseDTO, error) {
+	f.decisions = append(f.decisions, decision)
+	return dto.BidResponseDTO{ID: bidID, Status: decision}, nil
+}
+
+func TestSealedTenderFlow(t *testing.T) {
+	buyerOrg, supplierOrg := uuid.New(), uuid.New()
+	deadline := time.Now().Add(time.Hour)
+
+	db := &sealedStorage{
+		tender: dto.TenderResponseDTO{
+			ID:                 uuid.New(),
+			Status:             models.TenderStatusPublished.String(),
+			OrganizationID:     buyerOrg,
+			CreatorUsername:    "buyer",
+			SubmissionDeadline: &deadline,
+			Sealed:             true,
+		},
+		subjects: map[string]authz.Subject{
+			"buyer":    {UserID: uuid.New(), Username: "buyer", Organizations: []uuid.UUID{buyerOrg}},
+			"supplier": {UserID: uuid.New(), Username: "supplier", Organizations: []uuid.UUID{supplierOrg}},
+		},
+	}
+	db.bid = models.Bid{
+		ID:             uuid.New(),
+		Name:           "Delivery",
+		Status:         models.BidStatusPublished,
+		TenderID:       db.tender.ID,
+		OrganizationID: supplierOrg,
+		AuthorType:     "Organization",
+		AuthorID:       supplierOrg,
+		Version:        1,
+	}
+
+	s := NewBidService(slog.New(slog.NewTextHandler(io.Discard, nil)), db)
+	buyer := auth.WithPrincipal(context.Background(), auth.Principal{Username: "buyer"})
+	supplier := auth.WithPrincipal(context.Background(), auth.Principal{Username: "supplier"})
+	tenderID, bidID := db.tender.ID.String(), db.bid.ID.String()
+
+	t.Run("before the deadline the buyer sees only metadata", func(t *testing.T) {
+		page, err := s.GetTenderBids(buyer, tenderID, dto.ListQueryDTO{})
+		if err != nil {
+			t.Fatalf("GetTenderBids: %v", err)
+		}
+		if len(page.Items) != 1 {
+			t.Fatalf("got %d bids, want 1", len(page.Items))
+		}
+		bid := page.Items[0]
+		if !bid.Sealed || bid.Name != "" || bid.Price != nil || bid.ID != db.bid.ID {
+			t.Errorf("bid is not sealed: %+v", bid)
+		}
+	})
+
+	t.Run("before the deadline only the author views the bid", func(t *testing.T) {
+		if _, _, err := s.GetBidStatus(buyer, bidID); !errors.Is(err, authz.ErrForbidden) {
+			t.Errorf("buyer GetBidStatus error = %v, want %v", err, authz.ErrForbidden)
+		}
+		if _, _, err := s.GetBidStatus(supplier, bidID); err != nil {
+			t.Errorf("author GetBidStatus: %v", err)
+		}
+	})
+
+	t.Run("before the deadline decisions are refused", func(t *testing.T) {
+		_, err := s.SubmitDecision(buyer, bidID, models.BidStatusApproved.String())
+		if !errors.Is(err, repository.ErrBidsSealed) {
+			t.Errorf("SubmitDecision error = %v, want %v", err, repository.ErrBidsSealed)
+		}
+		if len(db.decisions) != 0 {
+			t.Errorf("decision reached storage: %v", db.decisions)
+		}
+	})
+
+	passed := time.Now().Add(-time.Minute)
+	db.tender.SubmissionDeadline = &passed
+
+	t.Run("after the deadline the buyer sees full bids", func(t *testing.T) {
+		page, err := s.GetTenderBids(buyer, tenderID, dto.ListQueryDTO{})
+		if err != nil {
+			t.Fatalf("GetTenderBids: %v", err)
+		}
+		bid := page.Items[0]
+		if bid.Sealed || bid.Name != db.bid.Name || bid.Price == nil {
+			t.Errorf("bid is still sealed: %+v", bid)
+		}
+		if _, _, err = s.GetBidStatus(buyer, bidID); err != nil {
+			t.Errorf("buyer GetBidStatus: %v", err)
+		}
+	})
+
+	t.Run("after the deadline decisions unlock", func(t *testing.T) {
+		if _, err := s.SubmitDecision(buyer, bidID, models.BidStatusApproved.String()); err != nil {
+			t.Fatalf("SubmitDecision: %v", err)
+		}
+		if len(db.decisions) != 1 || db.decisions[0] != models.BidStatusApproved.String() {
+			t.Errorf("decisions = %v, want one approval", db.decisions)
+		}
+	})
+
+	t.Run("the author cannot decide on their own bid", func(t *testing.T) {
+		if _, err := s.SubmitDecision(supplier, bidID, models.BidStatusApproved.String()); !errors.Is(err, authz.ErrForbidden) {
+			t.Errorf("SubmitDecision error = %v, want %v", err, authz.ErrForbidden)
+		}
+	})
+}
+
+func TestBidsSealed(t *testing.T) {
+	now := time.Now()
+	before, after := now.Add(time.Hour), now.Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		tender dto.TenderResponseDTO
+		want   bool
+	}{
+		{"not sealed", dto.TenderResponseDTO{SubmissionDeadline: &before}, false},
+		{"sealed before the deadline", dto.TenderResponseDTO{Sealed: true, SubmissionDeadline: &before}, true},
+		{"sealed at the deadline", dto.TenderResponseDTO{Sealed: true, SubmissionDeadline: &now}, false},
+		{"sealed after the deadline", dto.TenderResponseDTO{Sealed: true, SubmissionDeadline: &after}, false},
+		{"sealed without a deadline", dto.TenderResponseDTO{Sealed: true}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bidsSealed(tt.tender, now); got != tt.want {
+				t.Errorf("bidsSealed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
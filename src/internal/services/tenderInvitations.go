@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"git.codenrock.com/avito/internal/authz"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/domain/models"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+	"log/slog"
+)
+
+func (s *TenderService) GetTenderInvitations(ctx context.Context, tenderID string) ([]dto.TenderInvitationDTO, error) {
+	const op = "services.tenderService.GetTenderInvitations"
+
+	tenderUUID, _, err := s.authorizeInvitations(ctx, tenderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	invitations, err := s.db.GetTenderInvitations(ctx, tenderUUID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return invitations, nil
+}
+
+// InviteOrganization adds an organization to the invitation list. The list can be
+// prepared on a public tender and takes effect once the tender is invite-only.
+func (s *TenderService) InviteOrganization(ctx context.Context, tenderID string, invitation dto.InvitationDTO) (dto.TenderInvitationDTO, error) {
+	const op = "services.tenderService.InviteOrganization"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("tenderID", tenderID),
+		slog.String("organizationID", invitation.OrganizationID.String()),
+	)
+
+	tenderUUID, responsible, err := s.authorizeInvitations(ctx, tenderID)
+	if err != nil {
+		return dto.TenderInvitationDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if invitation.OrganizationID == uuid.Nil {
+		return dto.TenderInvitationDTO{}, fmt.Errorf("%s: %w", op, repository.ErrOrganizationNotFound)
+	}
+
+	log.Info("Inviting organization")
+
+	invited, err := s.db.CreateTenderInvitation(ctx, tenderUUID, invitation.OrganizationID, responsible.Username)
+	if err != nil {
+		return dto.TenderInvitationDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return invited, nil
+}
+
+// RevokeInvitation removes an organization from the invitation list. Bids the
+// organization has already submitted are kept.
+func (s *TenderService) RevokeInvitation(ctx context.Context, tenderID, organizationID string) error {
+	const op = "services.tenderService.RevokeInvitation"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("tenderID", tenderID),
+		slog.String("organizationID", organizationID),
+	)
+
+	organizationUUID, err := uuid.Parse(organizationID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, repository.ErrInvitationNotFound)
+	}
+
+	tenderUUID, _, err := s.authorizeInvitations(ctx, tenderID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("Revoking invitation")
+
+	if err = s.db.DeleteTenderInvitation(ctx, tenderUUID, organizationUUID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// authorizeInvitations checks that the caller manages the invitations of the tender.
+func (s *TenderService) authorizeInvitations(ctx context.Context, tenderID string) (uuid.UUID, authz.Subject, error) {
+	tenderUUID, err := uuid.Parse(tenderID)
+	if err != nil {
+		return uuid.Nil, authz.Subject{}, err
+	}
+
+	tender, err := s.db.GetTender(ctx, tenderUUID)
+	if err != nil {
+		return uuid.Nil, authz.Subject{}, err
+	}
+
+	responsible, err := authorize(ctx, s.db, authz.TenderInvite, tenderResource(tender))
+	if err != nil {
+		return uuid.Nil, authz.Subject{}, err
+	}
+
+	return tenderUUID, responsible, nil
+}
+
+// normalizeVisibility returns the canonical form of a requested tender visibility.
+// An empty value is kept empty so the caller can apply its default.
+func normalizeVisibility(visibility string) (string, error) {
+	if visibility == "" {
+		return "", nil
+	}
+
+	parsed, ok := models.ParseTenderVisibility(visibility)
+	if !ok {
+		return "", repository.ErrInvalidVisibility
+	}
+	return parsed.String(), nil
+}
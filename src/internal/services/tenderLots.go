@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"git.codenrock.com/avito/internal/auth"
+	"git.codenrock.com/avito/internal/authz"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/domain/models"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+)
+
+func (s *TenderService) GetTenderLots(ctx context.Context, tenderID string) ([]dto.TenderLotDTO, error) {
+	const op = "services.tenderService.GetTenderLots"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("tenderID", tenderID),
+	)
+
+	tenderUUID, err := uuid.Parse(tenderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tender, err := s.db.GetTender(ctx, tenderUUID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = authorize(ctx, s.db, authz.TenderView, tenderResource(tender)); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("Getting tender lots")
+
+	lots, err := s.db.GetTenderLots(ctx, tenderUUID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return lots, nil
+}
+
+// CreateTenderLots adds lots to a tender that has not been published yet, so bids
+// never refer to a set of lots that changed under them.
+func (s *TenderService) CreateTenderLots(ctx context.Context, tenderID string, lots []dto.LotDTO) ([]dto.TenderLotDTO, error) {
+	const op = "services.tenderService.CreateTenderLots"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("tenderID", tenderID),
+	)
+
+	tenderUUID, err := uuid.Parse(tenderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = validateLots(lots); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var created []dto.TenderLotDTO
+	err = s.db.WithinTx(ctx, func(ctx context.Context) error {
+		tender, err := s.db.LockTender(ctx, tenderUUID)
+		if err != nil {
+			return err
+		}
+
+		if _, err = authorize(ctx, s.db, authz.TenderEdit, tenderResource(tender)); err != nil {
+			return err
+		}
+
+		if tender.Status != models.TenderStatusCreated.String() {
+			log.Warn("Cannot add lots to a tender that is not in Created status", slog.String("status", tender.Status))
+			return repository.ErrLotsLocked
+		}
+
+		log.Info("Adding tender lots", slog.Int("count", len(lots)))
+
+		created, err = s.db.CreateTenderLots(ctx, tenderUUID, lots)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return created, nil
+}
+
+// CancelTenderLot cancels an open lot. Cancelling the last open lot closes a published
+// tender; before publication it is refused, since bids could never be placed on a
+// tender whose lots are all cancelled.
+func (s *TenderService) CancelTenderLot(ctx context.Context, tenderID, lotID string) (dto.TenderLotDTO, error) {
+	const op = "services.tenderService.CancelTenderLot"
+
+	username, err := auth.Username(ctx)
+	if err != nil {
+		return dto.TenderLotDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("tenderID", tenderID),
+		slog.String("lotID", lotID),
+	)
+
+	tenderUUID, err := uuid.Parse(tenderID)
+	if err != nil {
+		return dto.TenderLotDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+	lotUUID, err := uuid.Parse(lotID)
+	if err != nil {
+		return dto.TenderLotDTO{}, fmt.Errorf("%s: %w", op, repository.ErrLotNotFound)
+	}
+
+	var lot dto.TenderLotDTO
+	err = s.db.WithinTx(ctx, func(ctx context.Context) error {
+		tender, err := s.db.LockTender(ctx, tenderUUID)
+		if err != nil {
+			return err
+		}
+
+		if _, err = authorize(ctx, s.db, authz.TenderEdit, tenderResource(tender)); err != nil {
+			return err
+		}
+
+		if tender.Status == models.TenderStatusCreated.String() {
+			lots, err := s.db.GetTenderLots(ctx, tenderUUID)
+			if err != nil {
+				return err
+			}
+			if lastOpenLot(lots, lotUUID) {
+				log.Warn("Cannot cancel the last open lot of an unpublished tender")
+				return repository.ErrLastOpenLot
+			}
+		}
+
+		log.Info("Cancelling tender lot")
+
+		lot, err = s.db.CancelTenderLot(ctx, tenderUUID, lotUUID, username)
+		return err
+	})
+	if err != nil {
+		return dto.TenderLotDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return lot, nil
+}
+
+func validateLots(lots []dto.LotDTO) error {
+	for _, lot := range lots {
+		if strings.TrimSpace(lot.Name) == "" || utf8.RuneCountInString(lot.Name) > 100 || utf8.RuneCountInString(lot.Description) > 500 {
+			return repository.ErrInvalidLot
+		}
+	}
+	return nil
+}
+
+// lastOpenLot reports whether lotID is the only open lot among lots.
+func lastOpenLot(lots []dto.TenderLotDTO, lotID uuid.UUID) bool {
+	for _, lot := range lots {
+		if lot.ID != lotID && lot.Status == models.LotStatusOpen.String() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"git.codenrock.com/avito/internal/authz"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/domain/models"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+)
+
+// GetTenderQuestions lists the questions on a tender the caller may see. The tender
+// organization sees every question; everyone else who can view the tender sees the
+// publicly answered ones and their own.
+func (s *TenderService) GetTenderQuestions(ctx context.Context, tenderID string, q dto.ListQueryDTO) (dto.PageDTO[dto.TenderQuestionDTO], error) {
+	const op = "services.tenderService.GetTenderQuestions"
+
+	tenderUUID, err := uuid.Parse(tenderID)
+	if err != nil {
+		return dto.PageDTO[dto.TenderQuestionDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tender, err := s.db.GetTender(ctx, tenderUUID)
+	if err != nil {
+		return dto.PageDTO[dto.TenderQuestionDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	res := tenderResource(tender)
+	viewer, err := authorize(ctx, s.db, authz.TenderView, res)
+	if err != nil {
+		return dto.PageDTO[dto.TenderQuestionDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	questions, err := s.db.GetTenderQuestions(ctx, tenderUUID, viewer.UserID, authz.Can(viewer, authz.QuestionReadAll, res), q)
+	if err != nil {
+		return dto.PageDTO[dto.TenderQuestionDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return questions, nil
+}
+
+// AskTenderQuestion posts a question from any employee on a published tender.
+func (s *TenderService) AskTenderQuestion(ctx context.Context, tenderID string, question dto.QuestionDTO) (dto.TenderQuestionDTO, error) {
+	const op = "services.tenderService.AskTenderQuestion"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("tenderID", tenderID),
+	)
+
+	tenderUUID, err := uuid.Parse(tenderID)
+	if err != nil {
+		return dto.TenderQuestionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	question.Question = strings.TrimSpace(question.Question)
+	if question.Question == "" || utf8.RuneCountInString(question.Question) > 1000 {
+		return dto.TenderQuestionDTO{}, fmt.Errorf("%s: %w", op, repository.ErrInvalidQuestion)
+	}
+
+	tender, err := s.db.GetTender(ctx, tenderUUID)
+	if err != nil {
+		return dto.TenderQuestionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	res := tenderResource(tender)
+	author, err := authorize(ctx, s.db, authz.QuestionAsk, res)
+	if err != nil {
+		return dto.TenderQuestionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if !authz.Can(author, authz.TenderView, res) {
+		return dto.TenderQuestionDTO{}, fmt.Errorf("%s: %w", op, authz.ErrForbidden)
+	}
+
+	if tender.Status != models.TenderStatusPublished.String() {
+		log.Warn("Question on unpublished tender", slog.String("status", tender.Status))
+		return dto.TenderQuestionDTO{}, fmt.Errorf("%s: %w", op, repository.ErrTenderNotPublished)
+	}
+
+	log.Info("Posting question")
+
+	created, err := s.db.CreateTenderQuestion(ctx, tenderUUID, author.UserID, question.Question)
+	if err != nil {
+		return dto.TenderQuestionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return created, nil
+}
+
+// AnswerTenderQuestion sets the answer of a responsible of the tender organization.
+// Answering again replaces the previous answer and its visibility.
+func (s *TenderService) AnswerTenderQuestion(ctx context.Context, tenderID, questionID string, answer dto.AnswerDTO) (dto.TenderQuestionDTO, error) {
+	const op = "services.tenderService.AnswerTenderQuestion"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("tenderID", tenderID),
+		slog.String("questionID", questionID),
+	)
+
+	tenderUUID, err := uuid.Parse(tenderID)
+	if err != nil {
+		return dto.TenderQuestionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+	questionUUID, err := uuid.Parse(questionID)
+	if err != nil {
+		return dto.TenderQuestionDTO{}, fmt.Errorf("%s: %w", op, repository.ErrQuestionNotFound)
+	}
+
+	answer.Answer = strings.TrimSpace(answer.Answer)
+	if answer.Answer == "" || utf8.RuneCountInString(answer.Answer) > 2000 {
+		return dto.TenderQuestionDTO{}, fmt.Errorf("%s: %w", op, repository.ErrInvalidQuestion)
+	}
+
+	tender, err := s.db.GetTender(ctx, tenderUUID)
+	if err != nil {
+		return dto.TenderQuestionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	responsible, err := authorize(ctx, s.db, authz.QuestionAnswer, tenderResource(tender))
+	if err != nil {
+		return dto.TenderQuestionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("Answering question", slog.Bool("public", answer.Public))
+
+	answered, err := s.db.AnswerTenderQuestion(ctx, tenderUUID, questionUUID, responsible.UserID, answer)
+	if err != nil {
+		return dto.TenderQuestionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return answered, nil
+}
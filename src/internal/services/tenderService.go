@@ -3,22 +3,57 @@ package services
 import (
 	"context"
 	"fmt"
+	"git.codenrock.com/avito/internal/auth"
+	"git.codenrock.com/avito/internal/authz"
 	"git.codenrock.com/avito/internal/converter"
 	"git.codenrock.com/avito/internal/domain/dto"
 	"git.codenrock.com/avito/internal/domain/models"
+	"git.codenrock.com/avito/internal/repository"
 	"github.com/google/uuid"
 	"log/slog"
+	"time"
 )
 
+// TxManager runs a function in a transaction. Storage calls made with the context
+// passed to fn take part in it.
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type Storage interface {
-	GetTenders(ctx context.Context, serviceTypes []string, limit, offset int) ([]dto.TenderResponseDTO, error)
+	TxManager
+	SubjectStorage
+	GetTenders(ctx context.Context, viewer string, q dto.ListQueryDTO) (dto.PageDTO[dto.TenderResponseDTO], error)
 	CreateTender(ctx context.Context, tender dto.TenderDTO) (dto.TenderResponseDTO, error)
-	GetUserTenders(ctx context.Context, username string, limit, offset int) ([]dto.TenderResponseDTO, error)
-	GetTenderStatus(ctx context.Context, tenderID uuid.UUID, username string) (string, error)
-	UpdateTenderStatus(ctx context.Context, tenderID uuid.UUID, newStatus, username string) (dto.TenderResponseDTO, error)
+	GetUserTenders(ctx context.Context, username string, q dto.ListQueryDTO) (dto.PageDTO[dto.TenderResponseDTO], error)
+	GetTender(ctx context.Context, tenderID uuid.UUID) (dto.TenderResponseDTO, error)
+	LockTender(ctx context.Context, tenderID uuid.UUID) (dto.TenderResponseDTO, error)
+	UpdateTenderStatus(ctx context.Context, tenderID uuid.UUID, from, to models.TenderStatus, username string) (dto.TenderResponseDTO, error)
+	GetTenderStatusHistory(ctx context.Context, tenderID uuid.UUID) ([]dto.TenderStatusChangeDTO, error)
+	HasApprovedBids(ctx context.Context, tenderID uuid.UUID) (bool, error)
+	GetTenderVersions(ctx context.Context, tenderID uuid.UUID) ([]dto.VersionDTO, error)
 	UpdateTenderInfo(ctx context.Context, tenderID uuid.UUID, updatedData dto.UpdateTenderDTO, username string) (dto.TenderResponseDTO, error)
 	RollbackTenderVersion(ctx context.Context, tenderID uuid.UUID, version int, username string) (dto.TenderResponseDTO, error)
-	IsUserResponsibleForOrganization(ctx context.Context, username, organizationID string) (bool, error)
+	CloseExpiredTenders(ctx context.Context, actor string, decisionPeriod time.Duration, limit int) ([]uuid.UUID, error)
+	PublishScheduledTenders(ctx context.Context, actor string, limit int) ([]uuid.UUID, error)
+	GetTenderLots(ctx context.Context, tenderID uuid.UUID) ([]dto.TenderLotDTO, error)
+	CreateTenderLots(ctx context.Context, tenderID uuid.UUID, lots []dto.LotDTO) ([]dto.TenderLotDTO, error)
+	CancelTenderLot(ctx context.Context, tenderID, lotID uuid.UUID, username string) (dto.TenderLotDTO, error)
+	GetTenderCriteria(ctx context.Context, tenderID uuid.UUID) ([]dto.TenderCriterionDTO, error)
+	ReplaceTenderCriteria(ctx context.Context, tenderID uuid.UUID, criteria []dto.CriterionDTO) ([]dto.TenderCriterionDTO, error)
+	GetTenderScoreboard(ctx context.Context, tenderID uuid.UUID) ([]dto.BidRankingDTO, error)
+	GetTenderQuestions(ctx context.Context, tenderID, viewerID uuid.UUID, all bool, q dto.ListQueryDTO) (dto.PageDTO[dto.TenderQuestionDTO], error)
+	CreateTenderQuestion(ctx context.Context, tenderID, authorID uuid.UUID, question string) (dto.TenderQuestionDTO, error)
+	AnswerTenderQuestion(ctx context.Context, tenderID, questionID, answeredBy uuid.UUID, answer dto.AnswerDTO) (dto.TenderQuestionDTO, error)
+	GetTenderInvitations(ctx context.Context, tenderID uuid.UUID) ([]dto.TenderInvitationDTO, error)
+	CreateTenderInvitation(ctx context.Context, tenderID, organizationID uuid.UUID, invitedBy string) (dto.TenderInvitationDTO, error)
+	DeleteTenderInvitation(ctx context.Context, tenderID, organizationID uuid.UUID) error
+	GetTenderAuction(ctx context.Context, tenderID uuid.UUID) (dto.TenderAuctionDTO, error)
+	SaveTenderAuction(ctx context.Context, tenderID uuid.UUID, auction dto.AuctionDTO) (dto.TenderAuctionDTO, error)
+	LockTenderAuction(ctx context.Context, tenderID uuid.UUID) (dto.TenderAuctionDTO, error)
+	CloseTenderAuction(ctx context.Context, tenderID uuid.UUID, winningBidID *uuid.UUID) (dto.TenderAuctionDTO, error)
+	CloseEndedAuctions(ctx context.Context, limit int) ([]uuid.UUID, error)
+	SubmitDecision(ctx context.Context, bidID uuid.UUID, decision, username string) (dto.BidResponseDTO, error)
 }
 
 type TenderService struct {
@@ -30,6 +65,13 @@ var (
 	ErrTenderIDFieldEmpty = fmt.Errorf("tender id field is empty")
 )
 
+const (
+	// SchedulerActor is recorded as the author of status changes made by background jobs.
+	SchedulerActor = "system"
+
+	scheduledTendersBatch = 100
+)
+
 func NewTenderService(log *slog.Logger, db Storage) *TenderService {
 	return &TenderService{
 		log: log,
@@ -37,16 +79,23 @@ func NewTenderService(log *slog.Logger, db Storage) *TenderService {
 	}
 }
 
-func (s *TenderService) GetTenders(ctx context.Context, serviceTypes []string, limit, offset int) ([]dto.TenderResponseDTO, error) {
+func (s *TenderService) GetTenders(ctx context.Context, q dto.ListQueryDTO) (dto.PageDTO[dto.TenderResponseDTO], error) {
 	const op = "services.tenderService.GetTenders"
 
-	s.log.Info("Get tenders", slog.String("op", op))
+	s.log.Info("Get tenders", slog.String("op", op), slog.String("query", q.Query))
 
-	tenders, err := s.db.GetTenders(ctx, serviceTypes, limit, offset)
+	// Invite-only tenders are listed to the organizations they concern, so the
+	// viewer is passed along when known.
+	var viewer string
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		viewer = principal.Username
+	}
+
+	tenders, err := s.db.GetTenders(ctx, viewer, q)
 	if err != nil {
-		s.log.Error("failed to hash password", err)
+		s.log.Error("failed to get tenders", slog.String("error", err.Error()))
 
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return dto.PageDTO[dto.TenderResponseDTO]{}, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return tenders, nil
@@ -60,21 +109,48 @@ func (s *TenderService) CreateTender(ctx context.Context, tender *models.Tender)
 		slog.String("name", tender.Name),
 	)
 
-	tenderDto := converter.ToCreateTenderDTO(tender)
+	username, err := auth.Resolve(ctx, tender.CreatorUsername)
+	if err != nil {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+	tender.CreatorUsername = username
+	if _, ok := auth.PrincipalFromContext(ctx); !ok {
+		ctx = auth.WithPrincipal(ctx, auth.Principal{Username: username})
+	}
 
-	isResponsible, err := s.db.IsUserResponsibleForOrganization(ctx, tender.CreatorUsername, tender.OrganizationID.String())
+	_, err = authorize(ctx, s.db, authz.TenderCreate, authz.Resource{TenderOrganizationID: tender.OrganizationID})
 	if err != nil {
 		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if !isResponsible {
+	if err = validateDeadlines(tender.PublishAt, tender.SubmissionDeadline, tender.DecisionDeadline); err != nil {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = deadlinesInFuture(time.Now(), tender.PublishAt, tender.SubmissionDeadline, tender.DecisionDeadline); err != nil {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = validateBudget(tender.BudgetMin, tender.BudgetMax, tender.Currency); err != nil {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if tender.Visibility, err = normalizeVisibility(tender.Visibility); err != nil {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if tender.Visibility == "" {
+		tender.Visibility = models.TenderVisibilityPublic.String()
+	}
+	if tender.Sealed && tender.SubmissionDeadline == nil {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrSealedWithoutDeadline)
+	}
+
+	tenderDto := converter.ToCreateTenderDTO(tender)
+	if err = validateLots(tenderDto.Lots); err != nil {
 		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
 	createdTender, err := s.db.CreateTender(ctx, tenderDto)
 
 	if err != nil {
-		s.log.Error("failed to hash password", err)
+		s.log.Error("failed to hash password", slog.String("error", err.Error()))
 
 		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
@@ -82,25 +158,30 @@ func (s *TenderService) CreateTender(ctx context.Context, tender *models.Tender)
 	return createdTender, nil
 }
 
-func (s *TenderService) GetUserTenders(ctx context.Context, username string, limitInt, offsetInt int) ([]dto.TenderResponseDTO, error) {
+func (s *TenderService) GetUserTenders(ctx context.Context, q dto.ListQueryDTO) (dto.PageDTO[dto.TenderResponseDTO], error) {
 	const op = "services.tenderService.GetUserTenders"
 
+	username, err := auth.Username(ctx)
+	if err != nil {
+		return dto.PageDTO[dto.TenderResponseDTO]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log := s.log.With(
 		slog.String("op", op),
 		slog.String("username", username),
 	)
 
-	log.Info("Getting user tenders", slog.Int("limit", limitInt), slog.Int("offset", offsetInt))
+	log.Info("Getting user tenders", slog.Int("limit", q.Limit), slog.Int("offset", q.Offset))
 
-	tenders, err := s.db.GetUserTenders(ctx, username, limitInt, offsetInt)
-	if tenders == nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+	tenders, err := s.db.GetUserTenders(ctx, username, q)
+	if err != nil {
+		return dto.PageDTO[dto.TenderResponseDTO]{}, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return tenders, nil
 }
 
-func (s *TenderService) GetTenderStatus(ctx context.Context, tenderID, username string) (string, error) {
+func (s *TenderService) GetTenderStatus(ctx context.Context, tenderID string) (string, int, error) {
 	const op = "services.tenderService.GetTenderStatus"
 
 	log := s.log.With(
@@ -109,29 +190,38 @@ func (s *TenderService) GetTenderStatus(ctx context.Context, tenderID, username
 	)
 
 	if tenderID == "" {
-		return "", fmt.Errorf("%s: %w", op, ErrTenderIDFieldEmpty)
+		return "", 0, fmt.Errorf("%s: %w", op, ErrTenderIDFieldEmpty)
 	}
 
 	tenderUUID, err := uuid.Parse(tenderID)
 	if err != nil {
-		return "", fmt.Errorf("%s: %w", op, err)
+		return "", 0, fmt.Errorf("%s: %w", op, err)
 	}
 
 	log.Info("Getting tender status")
 
-	status, err := s.db.GetTenderStatus(ctx, tenderUUID, username)
+	tender, err := s.db.GetTender(ctx, tenderUUID)
 	if err != nil {
-		return "", fmt.Errorf("%s: %w", op, err)
+		return "", 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = authorize(ctx, s.db, authz.TenderView, tenderResource(tender)); err != nil {
+		return "", 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	log.Info("Got tender status", slog.String("status", status))
+	log.Info("Got tender status", slog.String("status", tender.Status))
 
-	return status, nil
+	return tender.Status, tender.Version, nil
 }
 
-func (s *TenderService) UpdateTenderStatus(ctx context.Context, tenderID, newStatus, username string) (dto.TenderResponseDTO, error) {
+func (s *TenderService) UpdateTenderStatus(ctx context.Context, tenderID, newStatus string, expectedVersion int) (dto.TenderResponseDTO, error) {
 	const op = "services.tenderService.UpdateTenderStatus"
 
+	username, err := auth.Username(ctx)
+	if err != nil {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log := s.log.With(
 		slog.String("op", op),
 		slog.String("tenderID", tenderID),
@@ -146,9 +236,55 @@ func (s *TenderService) UpdateTenderStatus(ctx context.Context, tenderID, newSta
 		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	log.Info("Updating tender status")
+	status, ok := models.ParseTenderStatus(newStatus)
+	if !ok {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, repository.ErrInvalidTenderStatus)
+	}
 
-	tender, err := s.db.UpdateTenderStatus(ctx, tenderUUID, newStatus, username)
+	var tender dto.TenderResponseDTO
+	err = s.db.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.db.LockTender(ctx, tenderUUID)
+		if err != nil {
+			return err
+		}
+
+		if _, err = authorize(ctx, s.db, authz.TenderPublish, tenderResource(current)); err != nil {
+			return err
+		}
+
+		if err = checkExpectedVersion(current.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		currentStatus, _ := models.ParseTenderStatus(current.Status)
+		if !currentStatus.CanTransitionTo(status) {
+			log.Warn("Illegal tender status transition", slog.String("from", current.Status), slog.String("to", status.String()))
+			return repository.ErrIllegalTenderTransition
+		}
+
+		if status == models.TenderStatusPublished {
+			if err = submissionOpen(current, time.Now()); err != nil {
+				log.Warn("Cannot publish tender after its submission deadline")
+				return err
+			}
+		}
+
+		if currentStatus == models.TenderStatusClosed {
+			approved, err := s.db.HasApprovedBids(ctx, tenderUUID)
+			if err != nil {
+				return err
+			}
+			if approved {
+				log.Warn("Cannot reopen tender with an approved bid")
+				return repository.ErrIllegalTenderTransition
+			}
+		}
+
+		log.Info("Updating tender status", slog.String("from", current.Status), slog.String("to", status.String()))
+
+		tender, err = s.db.UpdateTenderStatus(ctx, tenderUUID, currentStatus, status, username)
+		return err
+	})
 	if err != nil {
 		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
@@ -156,9 +292,50 @@ func (s *TenderService) UpdateTenderStatus(ctx context.Context, tenderID, newSta
 	return tender, nil
 }
 
-func (s *TenderService) UpdateTenderInfo(ctx context.Context, tenderID string, updatedData dto.UpdateTenderDTO, username string) (dto.TenderResponseDTO, error) {
+func (s *TenderService) GetTenderStatusHistory(ctx context.Context, tenderID string) ([]dto.TenderStatusChangeDTO, error) {
+	const op = "services.tenderService.GetTenderStatusHistory"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("tenderID", tenderID),
+	)
+
+	if tenderID == "" {
+		return nil, fmt.Errorf("%s: %w", op, ErrTenderIDFieldEmpty)
+	}
+
+	tenderUUID, err := uuid.Parse(tenderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tender, err := s.db.GetTender(ctx, tenderUUID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = authorize(ctx, s.db, authz.TenderView, tenderResource(tender)); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("Getting tender status history")
+
+	history, err := s.db.GetTenderStatusHistory(ctx, tenderUUID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return history, nil
+}
+
+func (s *TenderService) UpdateTenderInfo(ctx context.Context, tenderID string, updatedData dto.UpdateTenderDTO, expectedVersion int) (dto.TenderResponseDTO, error) {
 	const op = "services.tenderService.UpdateTender"
 
+	username, err := auth.Username(ctx)
+	if err != nil {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log := s.log.With(
 		slog.String("op", op),
 		slog.String("tenderID", tenderID),
@@ -173,9 +350,78 @@ func (s *TenderService) UpdateTenderInfo(ctx context.Context, tenderID string, u
 		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	log.Info("Updating tender")
-
-	tender, err := s.db.UpdateTenderInfo(ctx, tenderUUID, updatedData, username)
+	var tender dto.TenderResponseDTO
+	err = s.db.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.db.LockTender(ctx, tenderUUID)
+		if err != nil {
+			return err
+		}
+
+		if _, err = authorize(ctx, s.db, authz.TenderEdit, tenderResource(current)); err != nil {
+			return err
+		}
+
+		if err = checkExpectedVersion(current.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		if updatedData.PublishAt.Time != nil && current.Status != models.TenderStatusCreated.String() {
+			log.Warn("Cannot schedule publication of a tender that is not in Created status", slog.String("status", current.Status))
+			return repository.ErrIllegalTenderTransition
+		}
+
+		publishAt := mergeDeadline(current.PublishAt, updatedData.PublishAt)
+		if current.Status != models.TenderStatusCreated.String() {
+			publishAt = nil
+		}
+		submission := mergeDeadline(current.SubmissionDeadline, updatedData.SubmissionDeadline)
+		decision := mergeDeadline(current.DecisionDeadline, updatedData.DecisionDeadline)
+		if err = validateDeadlines(publishAt, submission, decision); err != nil {
+			return err
+		}
+		if err = deadlinesInFuture(time.Now(), updatedData.PublishAt.Time, updatedData.SubmissionDeadline.Time, updatedData.DecisionDeadline.Time); err != nil {
+			return err
+		}
+		if updatedData.SubmissionDeadline.Set {
+			if err = s.checkAuctionDeadline(ctx, tenderUUID, submission); err != nil {
+				return err
+			}
+		}
+
+		budgetMin, budgetMax, currency := current.BudgetMin, current.BudgetMax, current.Currency
+		if updatedData.BudgetMin != nil {
+			budgetMin = updatedData.BudgetMin
+		}
+		if updatedData.BudgetMax != nil {
+			budgetMax = updatedData.BudgetMax
+		}
+		if updatedData.Currency != "" {
+			currency = updatedData.Currency
+		}
+		if err = validateBudget(budgetMin, budgetMax, currency); err != nil {
+			return err
+		}
+		if updatedData.Visibility, err = normalizeVisibility(updatedData.Visibility); err != nil {
+			return err
+		}
+
+		sealed := current.Sealed
+		if updatedData.Sealed != nil && *updatedData.Sealed != current.Sealed {
+			if current.Status != models.TenderStatusCreated.String() {
+				log.Warn("Cannot change sealing of a published tender", slog.String("status", current.Status))
+				return repository.ErrSealingLocked
+			}
+			sealed = *updatedData.Sealed
+		}
+		if sealed && submission == nil {
+			return repository.ErrSealedWithoutDeadline
+		}
+
+		log.Info("Updating tender")
+
+		tender, err = s.db.UpdateTenderInfo(ctx, tenderUUID, updatedData, username)
+		return err
+	})
 	if err != nil {
 		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
@@ -185,9 +431,14 @@ func (s *TenderService) UpdateTenderInfo(ctx context.Context, tenderID string, u
 	return tender, nil
 }
 
-func (s *TenderService) RollbackTenderVersion(ctx context.Context, tenderID string, version int, username string) (dto.TenderResponseDTO, error) {
+func (s *TenderService) RollbackTenderVersion(ctx context.Context, tenderID string, version int, expectedVersion int) (dto.TenderResponseDTO, error) {
 	const op = "services.tenderService.RollbackTenderVersion"
 
+	username, err := auth.Username(ctx)
+	if err != nil {
+		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log := s.log.With(
 		slog.String("op", op),
 		slog.String("tenderID", tenderID),
@@ -202,9 +453,26 @@ func (s *TenderService) RollbackTenderVersion(ctx context.Context, tenderID stri
 		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	log.Info("Rolling back tender version")
+	var tender dto.TenderResponseDTO
+	err = s.db.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.db.LockTender(ctx, tenderUUID)
+		if err != nil {
+			return err
+		}
+
+		if _, err = authorize(ctx, s.db, authz.TenderEdit, tenderResource(current)); err != nil {
+			return err
+		}
 
-	tender, err := s.db.RollbackTenderVersion(ctx, tenderUUID, version, username)
+		if err = checkExpectedVersion(current.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		log.Info("Rolling back tender version")
+
+		tender, err = s.db.RollbackTenderVersion(ctx, tenderUUID, version, username)
+		return err
+	})
 	if err != nil {
 		return dto.TenderResponseDTO{}, fmt.Errorf("%s: %w", op, err)
 	}
@@ -213,3 +481,130 @@ func (s *TenderService) RollbackTenderVersion(ctx context.Context, tenderID stri
 
 	return tender, nil
 }
+
+func (s *TenderService) GetTenderVersions(ctx context.Context, tenderID string) ([]dto.VersionDTO, error) {
+	const op = "services.tenderService.GetTenderVersions"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("tenderID", tenderID),
+	)
+
+	tenderUUID, err := s.authorizeHistory(ctx, tenderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("Getting tender versions")
+
+	versions, err := s.db.GetTenderVersions(ctx, tenderUUID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return versions, nil
+}
+
+func (s *TenderService) GetTenderVersion(ctx context.Context, tenderID string, version int) (dto.VersionDTO, error) {
+	const op = "services.tenderService.GetTenderVersion"
+
+	versions, err := s.GetTenderVersions(ctx, tenderID)
+	if err != nil {
+		return dto.VersionDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	v, ok := findVersion(versions, version)
+	if !ok {
+		return dto.VersionDTO{}, fmt.Errorf("%s: %w", op, repository.ErrVersionNotFound)
+	}
+
+	return v, nil
+}
+
+func (s *TenderService) GetTenderDiff(ctx context.Context, tenderID string, from, to int) (dto.VersionDiffDTO, error) {
+	const op = "services.tenderService.GetTenderDiff"
+
+	versions, err := s.GetTenderVersions(ctx, tenderID)
+	if err != nil {
+		return dto.VersionDiffDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	diff, err := diffVersions(versions, from, to)
+	if err != nil {
+		return dto.VersionDiffDTO{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return diff, nil
+}
+
+// authorizeHistory parses the tender id and checks that the caller may read the
+// version history of the tender.
+func (s *TenderService) authorizeHistory(ctx context.Context, tenderID string) (uuid.UUID, error) {
+	if tenderID == "" {
+		return uuid.Nil, ErrTenderIDFieldEmpty
+	}
+
+	tenderUUID, err := uuid.Parse(tenderID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	tender, err := s.db.GetTender(ctx, tenderUUID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if _, err = authorize(ctx, s.db, authz.TenderHistory, tenderResource(tender)); err != nil {
+		return uuid.Nil, err
+	}
+
+	return tenderUUID, nil
+}
+
+// CloseExpiredTenders closes published tenders whose decision deadline has passed, or
+// that have only a submission deadline and were left open for the default decision
+// period after it. Between the two the tender stays published so that its bids can
+// still be decided. It is run periodically by the scheduler.
+func (s *TenderService) CloseExpiredTenders(ctx context.Context) error {
+	const op = "services.tenderService.CloseExpiredTenders"
+
+	log := s.log.With(slog.String("op", op))
+
+	for {
+		closed, err := s.db.CloseExpiredTenders(ctx, SchedulerActor, defaultDecisionPeriod, scheduledTendersBatch)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, id := range closed {
+			log.Info("Tender closed after decision deadline", slog.String("tenderID", id.String()))
+		}
+
+		if len(closed) < scheduledTendersBatch {
+			return nil
+		}
+	}
+}
+
+// PublishScheduledTenders publishes created tenders whose publish_at has come. It is
+// run periodically by the scheduler.
+func (s *TenderService) PublishScheduledTenders(ctx context.Context) error {
+	const op = "services.tenderService.PublishScheduledTenders"
+
+	log := s.log.With(slog.String("op", op))
+
+	for {
+		published, err := s.db.PublishScheduledTenders(ctx, SchedulerActor, scheduledTendersBatch)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, id := range published {
+			log.Info("Scheduled tender published", slog.String("tenderID", id.String()))
+		}
+
+		if len(published) < scheduledTendersBatch {
+			return nil
+		}
+	}
+}
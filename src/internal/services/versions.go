@@ -0,0 +1,110 @@
+package services
+
+import (
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+	"strings"
+)
+
+// checkExpectedVersion rejects a change based on a version other than the current
+// one. An expected version of zero makes the change unconditional.
+func checkExpectedVersion(current, expected int) error {
+	if expected != 0 && current != expected {
+		return repository.ErrVersionConflict
+	}
+	return nil
+}
+
+func findVersion(versions []dto.VersionDTO, version int) (dto.VersionDTO, bool) {
+	for _, v := range versions {
+		if v.Version == version {
+			return v, true
+		}
+	}
+	return dto.VersionDTO{}, false
+}
+
+// diffVersions reports the net field changes between two versions together with
+// every edit in between and who made it. versions must be sorted by version.
+func diffVersions(versions []dto.VersionDTO, from, to int) (dto.VersionDiffDTO, error) {
+	fromVersion, ok := findVersion(versions, from)
+	if !ok {
+		return dto.VersionDiffDTO{}, repository.ErrVersionNotFound
+	}
+	toVersion, ok := findVersion(versions, to)
+	if !ok {
+		return dto.VersionDiffDTO{}, repository.ErrVersionNotFound
+	}
+
+	diff := dto.VersionDiffDTO{
+		From:    from,
+		To:      to,
+		Changes: compareVersions(fromVersion, toVersion),
+		Edits:   []dto.VersionEditDTO{},
+	}
+
+	low, high := from, to
+	if low > high {
+		low, high = high, low
+	}
+	for i := 1; i < len(versions); i++ {
+		if versions[i].Version <= low || versions[i].Version > high {
+			continue
+		}
+		diff.Edits = append(diff.Edits, dto.VersionEditDTO{
+			Version:  versions[i].Version,
+			EditedBy: versions[i].EditedBy,
+			EditedAt: versions[i].CreatedAt,
+			Changes:  compareVersions(versions[i-1], versions[i]),
+		})
+	}
+
+	return diff, nil
+}
+
+func compareVersions(a, b dto.VersionDTO) []dto.FieldChangeDTO {
+	fields := []dto.FieldChangeDTO{
+		{Field: "name", From: a.Name, To: b.Name},
+		{Field: "description", From: a.Description, To: b.Description},
+		{Field: "service_type", From: a.ServiceType, To: b.ServiceType},
+		{Field: "status", From: a.Status, To: b.Status},
+		{Field: "price", From: formatPrice(a.Price), To: formatPrice(b.Price)},
+		{Field: "currency", From: a.Currency, To: b.Currency},
+		{Field: "items", From: formatItems(a.Items), To: formatItems(b.Items)},
+		{Field: "lot_ids", From: formatLotIDs(a.LotIDs), To: formatLotIDs(b.LotIDs)},
+	}
+
+	changes := []dto.FieldChangeDTO{}
+	for _, field := range fields {
+		if field.From != field.To {
+			changes = append(changes, field)
+		}
+	}
+	return changes
+}
+
+func formatPrice(price *string) string {
+	if price == nil {
+		return ""
+	}
+	return *price
+}
+
+// formatItems renders line items as "name: quantity x unit price" separated by
+// semicolons.
+func formatItems(items []dto.BidItemDTO) string {
+	parts := make([]string, 0, len(items))
+	for _, item := range items {
+		parts = append(parts, item.Name+": "+item.Quantity+" x "+item.UnitPrice)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func formatLotIDs(ids []uuid.UUID) string {
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, id.String())
+	}
+	return strings.Join(parts, ", ")
+}
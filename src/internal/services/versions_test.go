@@ -0,0 +1,129 @@
+package services
+
+import (
+	"errors"
+	"git.codenrock.com/avito/internal/domain/dto"
+	"git.codenrock.com/avito/internal/repository"
+	"github.com/google/uuid"
+	"reflect"
+	"testing"
+)
+
+func TestDiffVersions(t *testing.T) {
+	price1, price2 := "100.00", "90.00"
+	lot := uuid.New()
+
+	versions := []dto.VersionDTO{
+		{Version: 1, Name: "Bid", Status: "Created", EditedBy: "alice", Price: &price1, Currency: "RUB"},
+		{Version: 2, Name: "Better bid", Status: "Created", EditedBy: "bob", Price: &price1, Currency: "RUB"},
+		{Version: 3, Name: "Better bid", Status: "Published", EditedBy: "alice", Price: &price2, Currency: "RUB",
+			Items: []dto.BidItemDTO{{Name: "Pipe", Quantity: "3", UnitPrice: "30.00"}}, LotIDs: []uuid.UUID{lot}},
+	}
+
+	tests := []struct {
+		name      string
+		from, to  int
+		want      []dto.FieldChangeDTO
+		wantEdits []int
+		wantErr   error
+	}{
+		{
+			name: "forward over several edits",
+			from: 1, to: 3,
+			want: []dto.FieldChangeDTO{
+				{Field: "name", From: "Bid", To: "Better bid"},
+				{Field: "status", From: "Created", To: "Published"},
+				{Field: "price", From: "100.00", To: "90.00"},
+				{Field: "items", From: "", To: "Pipe: 3 x 30.00"},
+				{Field: "lot_ids", From: "", To: lot.String()},
+			},
+			wantEdits: []int{2, 3},
+		},
+		{
+			name: "backward",
+			from: 2, to: 1,
+			want:      []dto.FieldChangeDTO{{Field: "name", From: "Better bid", To: "Bid"}},
+			wantEdits: []int{2},
+		},
+		{
+			name: "same version",
+			from: 2, to: 2,
+			want:      []dto.FieldChangeDTO{},
+			wantEdits: []int{},
+		},
+		{name: "unknown from", from: 0, to: 2, wantErr: repository.ErrVersionNotFound},
+		{name: "unknown to", from: 1, to: 4, wantErr: repository.ErrVersionNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := diffVersions(versions, tt.from, tt.to)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("diffVersions() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("diffVersions() error = %v", err)
+			}
+
+			if diff.From != tt.from || diff.To != tt.to {
+				t.Errorf("diff range = %d..%d, want %d..%d", diff.From, diff.To, tt.from, tt.to)
+			}
+			if !reflect.DeepEqual(diff.Changes, tt.want) {
+				t.Errorf("changes = %+v, want %+v", diff.Changes, tt.want)
+			}
+
+			edits := []int{}
+			for _, edit := range diff.Edits {
+				edits = append(edits, edit.Version)
+			}
+			if !reflect.DeepEqual(edits, tt.wantEdits) {
+				t.Errorf("edits = %v, want %v", edits, tt.wantEdits)
+			}
+		})
+	}
+}
+
+func TestDiffVersionsEdits(t *testing.T) {
+	versions := []dto.VersionDTO{
+		{Version: 1, Name: "Tender", ServiceType: "Delivery", Status: "Created", EditedBy: "alice"},
+		{Version: 2, Name: "Tender", ServiceType: "Construction", Status: "Created", EditedBy: "bob"},
+	}
+
+	diff, err := diffVersions(versions, 1, 2)
+	if err != nil {
+		t.Fatalf("diffVersions() error = %v", err)
+	}
+
+	want := []dto.VersionEditDTO{{
+		Version:  2,
+		EditedBy: "bob",
+		Changes:  []dto.FieldChangeDTO{{Field: "service_type", From: "Delivery", To: "Construction"}},
+	}}
+	if !reflect.DeepEqual(diff.Edits, want) {
+		t.Errorf("edits = %+v, want %+v", diff.Edits, want)
+	}
+}
+
+func TestCheckExpectedVersion(t *testing.T) {
+	tests := []struct {
+		name              string
+		current, expected int
+		wantErr           error
+	}{
+		{"unconditional", 3, 0, nil},
+		{"matches", 3, 3, nil},
+		{"stale", 3, 2, repository.ErrVersionConflict},
+		{"ahead", 3, 4, repository.ErrVersionConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkExpectedVersion(tt.current, tt.expected); !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkExpectedVersion() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}